@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,29 +21,66 @@ var (
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
-		setup := ui.NewSetupModel()
-		p := tea.NewProgram(setup)
+		cfg = runSetup(nil)
+	}
+
+	for {
+		client := api.NewClient(cfg.Server.URL, cfg.Server.Token)
+		client.SetRetryPolicy(retryPolicy(cfg.Server.Retry))
+
+		state := config.LoadState()
+		app := ui.NewApp(client, state)
+		app.SetConfig(cfg)
+		p := tea.NewProgram(app, tea.WithAltScreen())
+		client.SetRetryObserver(func(ev api.RetryEvent) { p.Send(ev) })
+
 		result, err := p.Run()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Setup error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		setupModel, ok := result.(*ui.SetupModel)
-		if !ok || setupModel.Config() == nil {
-			fmt.Fprintf(os.Stderr, "Setup cancelled.\n")
-			os.Exit(0)
+		if a, ok := result.(*ui.App); !ok || !a.RerunSetup() {
+			return
 		}
-		cfg = setupModel.Config()
+		cfg = runSetup(cfg)
 	}
+}
 
-	client := api.NewClient(cfg.Server.URL, cfg.Server.Token)
-
-	state := config.LoadState()
-	app := ui.NewApp(client, state)
-	p := tea.NewProgram(app, tea.WithAltScreen())
-
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+// runSetup runs the interactive setup wizard and exits the process if it is
+// cancelled. When existing is non-nil only the token is asked for again.
+func runSetup(existing *config.Config) *config.Config {
+	setup := ui.NewSetupModel()
+	if existing != nil {
+		setup.Reauthenticate(existing)
+	}
+	p := tea.NewProgram(setup)
+	result, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Setup error: %v\n", err)
 		os.Exit(1)
 	}
+	setupModel, ok := result.(*ui.SetupModel)
+	if !ok || setupModel.Config() == nil {
+		fmt.Fprintf(os.Stderr, "Setup cancelled.\n")
+		os.Exit(0)
+	}
+	return setupModel.Config()
+}
+
+// retryPolicy overlays the non-zero values from the config onto the client defaults.
+func retryPolicy(rc config.RetryConfig) api.RetryPolicy {
+	policy := api.DefaultRetryPolicy()
+	if rc.MaxAttempts > 0 {
+		policy.MaxAttempts = rc.MaxAttempts
+	}
+	if rc.BaseDelay > 0 {
+		policy.BaseDelay = rc.BaseDelay
+	}
+	if rc.MaxDelay > 0 {
+		policy.MaxDelay = rc.MaxDelay
+	}
+	if rc.RetryWrites {
+		policy.Methods = append(policy.Methods, http.MethodPost, http.MethodDelete)
+	}
+	return policy
 }
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const activityEntityFields = "id,name,login,fullName,idReadable,text,presentation"
+
+const activityFields = "id,timestamp,author(login,fullName),category(id),field(name)," +
+	"added(" + activityEntityFields + "),removed(" + activityEntityFields + ")"
+
+// maxActivities caps how far back an issue's history is loaded.
+const maxActivities = 100
+
+// ListActivities returns the most recent changes to an issue in chronological
+// order, oldest first. The server is asked for them newest first so that the
+// cap keeps the latest ones.
+func (c *Client) ListActivities(ctx context.Context, issueID string) ([]model.Activity, error) {
+	params := url.Values{}
+	params.Set("fields", activityFields)
+	params.Set("categories", strings.Join(model.ActivityCategories, ","))
+	params.Set("reverse", "true")
+	params.Set("$top", strconv.Itoa(maxActivities))
+
+	resp, err := c.get(ctx, "/api/issues/"+url.PathEscape(issueID)+"/activities", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing activities for %s: %w", issueID, err)
+	}
+	defer resp.Body.Close()
+
+	var activities []model.Activity
+	if err := json.NewDecoder(resp.Body).Decode(&activities); err != nil {
+		return nil, fmt.Errorf("decoding activities: %w", err)
+	}
+	slices.Reverse(activities)
+
+	return activities, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_ListActivities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1/activities" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if !strings.Contains(q.Get("categories"), "CustomFieldCategory") || !strings.Contains(q.Get("categories"), "CommentsCategory") {
+			t.Errorf("unexpected categories: %s", q.Get("categories"))
+		}
+		if q.Get("reverse") != "true" {
+			t.Errorf("expected the latest activities requested, got reverse=%q", q.Get("reverse"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"a-1","timestamp":1707300000000,"author":{"login":"jane","fullName":"Jane Doe"},"category":{"id":"CustomFieldCategory"},"field":{"name":"State"},"added":[{"name":"Fixed"}],"removed":[{"name":"Open"}]},` +
+			`{"id":"a-0","timestamp":1707200000000,"author":{"login":"jane"},"category":{"id":"CommentsCategory"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	activities, err := client.ListActivities(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(activities) != 2 {
+		t.Fatalf("got %d activities, want 2", len(activities))
+	}
+	if activities[0].ID != "a-0" {
+		t.Errorf("expected oldest first, got %s", activities[0].ID)
+	}
+	a := activities[1]
+	if a.Author == nil || a.Author.FullName != "Jane Doe" || a.Timestamp != 1707300000000 {
+		t.Errorf("unexpected activity: %+v", a)
+	}
+	if got := a.Describe(); got != "State: Open → Fixed" {
+		t.Errorf("got %q, want State: Open → Fixed", got)
+	}
+}
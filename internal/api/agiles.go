@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const (
+	sprintFields = "id,name,start,finish,archived"
+	agileFields  = "id,name,projects(id,name,shortName),columnSettings(field(id,name),columns(id,presentation,fieldValues(id,name))),currentSprint(" + sprintFields + ")"
+)
+
+// ListAgiles returns the agile boards visible to the current user.
+func (c *Client) ListAgiles(ctx context.Context) ([]model.Agile, error) {
+	params := url.Values{}
+	params.Set("fields", agileFields)
+	params.Set("$top", "-1")
+
+	resp, err := c.get(ctx, "/api/agiles", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing agile boards: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var agiles []model.Agile
+	if err := json.NewDecoder(resp.Body).Decode(&agiles); err != nil {
+		return nil, fmt.Errorf("decoding agile boards: %w", err)
+	}
+
+	return agiles, nil
+}
+
+// GetSprint returns a sprint of the board with its issues. sprintID may be
+// "current" for the board's current sprint.
+func (c *Client) GetSprint(ctx context.Context, agileID, sprintID string) (*model.Sprint, error) {
+	params := url.Values{}
+	params.Set("fields", sprintFields+",issues("+issueListFields+")")
+
+	resp, err := c.get(ctx, agileSprintsPath(agileID)+"/"+url.PathEscape(sprintID), params)
+	if err != nil {
+		return nil, fmt.Errorf("getting sprint %s: %w", sprintID, err)
+	}
+	defer resp.Body.Close()
+
+	var sprint model.Sprint
+	if err := json.NewDecoder(resp.Body).Decode(&sprint); err != nil {
+		return nil, fmt.Errorf("decoding sprint: %w", err)
+	}
+
+	return &sprint, nil
+}
+
+// ListSprints returns the sprints of a board, without their issues.
+func (c *Client) ListSprints(ctx context.Context, agileID string) ([]model.Sprint, error) {
+	params := url.Values{}
+	params.Set("fields", sprintFields)
+	params.Set("$top", "-1")
+
+	resp, err := c.get(ctx, agileSprintsPath(agileID), params)
+	if err != nil {
+		return nil, fmt.Errorf("listing sprints: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var sprints []model.Sprint
+	if err := json.NewDecoder(resp.Body).Decode(&sprints); err != nil {
+		return nil, fmt.Errorf("decoding sprints: %w", err)
+	}
+
+	return sprints, nil
+}
+
+// AddIssueToSprint adds the issue to a sprint of the board.
+func (c *Client) AddIssueToSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	body, err := json.Marshal(map[string]string{"idReadable": issueID, "$type": "Issue"})
+	if err != nil {
+		return fmt.Errorf("marshaling issue: %w", err)
+	}
+
+	resp, err := c.post(ctx, sprintIssuesPath(agileID, sprintID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("adding %s to sprint: %w", issueID, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveIssueFromSprint takes the issue out of a sprint of the board.
+func (c *Client) RemoveIssueFromSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	if err := c.doDelete(ctx, sprintIssuesPath(agileID, sprintID)+"/"+url.PathEscape(issueID)); err != nil {
+		return fmt.Errorf("removing %s from sprint: %w", issueID, err)
+	}
+	return nil
+}
+
+func agileSprintsPath(agileID string) string {
+	return "/api/agiles/" + url.PathEscape(agileID) + "/sprints"
+}
+
+func sprintIssuesPath(agileID, sprintID string) string {
+	return agileSprintsPath(agileID) + "/" + url.PathEscape(sprintID) + "/issues"
+}
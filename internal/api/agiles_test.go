@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_ListAgiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/agiles" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("fields") != agileFields {
+			t.Errorf("unexpected fields: %s", r.URL.Query().Get("fields"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{
+			"id":"108-1","name":"Team board",
+			"projects":[{"id":"0-1","shortName":"PROJ"}],
+			"columnSettings":{"field":{"id":"f-1","name":"State"},"columns":[
+				{"id":"c-1","presentation":"Open","fieldValues":[{"id":"v-1","name":"Open"}]},
+				{"id":"c-2","presentation":"Done","fieldValues":[{"id":"v-2","name":"Fixed"},{"id":"v-3","name":"Verified"}]}
+			]},
+			"currentSprint":{"id":"109-4","name":"Sprint 4","start":1751320800000,"finish":1752530400000}
+		}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	agiles, err := client.ListAgiles(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(agiles) != 1 {
+		t.Fatalf("got %d boards, want 1", len(agiles))
+	}
+	a := agiles[0]
+	if a.ColumnField() != "State" || len(a.ColumnSettings.Columns) != 2 || a.ColumnSettings.Columns[1].FieldValues[1].Name != "Verified" {
+		t.Errorf("unexpected columns: %+v", a.ColumnSettings)
+	}
+	if a.CurrentSprint == nil || a.CurrentSprint.ID != "109-4" || a.CurrentSprint.Finish != 1752530400000 {
+		t.Errorf("unexpected current sprint: %+v", a.CurrentSprint)
+	}
+}
+
+func TestClient_GetSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/agiles/108-1/sprints/109-4" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if !strings.Contains(r.URL.Query().Get("fields"), "issues(id,idReadable,summary") {
+			t.Errorf("expected issue fields, got %s", r.URL.Query().Get("fields"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"109-4","name":"Sprint 4","issues":[
+			{"idReadable":"PROJ-1","summary":"First","customFields":[{"name":"State","$type":"StateIssueCustomField","value":{"name":"Open"}}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	sprint, err := client.GetSprint(context.Background(), "108-1", "109-4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sprint.Name != "Sprint 4" || len(sprint.Issues) != 1 || sprint.Issues[0].StateValue() != "Open" {
+		t.Errorf("unexpected sprint: %+v", sprint)
+	}
+}
+
+func TestClient_ListSprints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/agiles/108-1/sprints" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("fields") != sprintFields {
+			t.Errorf("unexpected fields: %s", r.URL.Query().Get("fields"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"109-3","name":"Sprint 3","archived":true},{"id":"109-4","name":"Sprint 4","start":1751320800000,"finish":1752530400000}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	sprints, err := client.ListSprints(context.Background(), "108-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sprints) != 2 || !sprints[0].Archived || sprints[1].Start != 1751320800000 {
+		t.Errorf("unexpected sprints: %+v", sprints)
+	}
+}
+
+func TestClient_AddIssueToSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/agiles/108-1/sprints/109-4/issues" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["idReadable"] != "PROJ-1" || body["$type"] != "Issue" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddIssueToSprint(context.Background(), "108-1", "109-4", "PROJ-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_RemoveIssueFromSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/agiles/108-1/sprints/109-4/issues/PROJ-1" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.RemoveIssueFromSprint(context.Background(), "108-1", "109-4", "PROJ-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
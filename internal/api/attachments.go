@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const attachmentFields = "id,name,author(login,fullName),created,size,mimeType,url"
+
+func (c *Client) ListAttachments(ctx context.Context, issueID string) ([]model.Attachment, error) {
+	params := url.Values{}
+	params.Set("fields", attachmentFields)
+
+	resp, err := c.get(ctx, "/api/issues/"+url.PathEscape(issueID)+"/attachments", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing attachments for %s: %w", issueID, err)
+	}
+	defer resp.Body.Close()
+
+	var attachments []model.Attachment
+	if err := json.NewDecoder(resp.Body).Decode(&attachments); err != nil {
+		return nil, fmt.Errorf("decoding attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DownloadAttachment streams the content of an attachment into w. URLs on a
+// foreign host are fetched without credentials.
+func (c *Client) DownloadAttachment(ctx context.Context, attachment model.Attachment, w io.Writer) error {
+	if attachment.URL == "" {
+		return fmt.Errorf("attachment %s has no download URL", attachment.Name)
+	}
+	fileURL, err := c.resolveURL(attachment.URL)
+	if err != nil {
+		return fmt.Errorf("resolving URL of %s: %w", attachment.Name, err)
+	}
+
+	resp, err := c.doRequestURL(ctx, http.MethodGet, fileURL, "", nil)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", attachment.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("downloading %s: %w", attachment.Name, err)
+	}
+	return nil
+}
+
+// UploadAttachment attaches the content of r to the issue under the given file name.
+func (c *Client) UploadAttachment(ctx context.Context, issueID, name string, r io.Reader) ([]model.Attachment, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", name)
+	if err != nil {
+		return nil, fmt.Errorf("creating upload form: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("creating upload form: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", attachmentFields)
+	reqURL := c.baseURL + "/api/issues/" + url.PathEscape(issueID) + "/attachments?" + params.Encode()
+
+	resp, err := c.doRequestURL(ctx, http.MethodPost, reqURL, mw.FormDataContentType(), &body)
+	if err != nil {
+		return nil, fmt.Errorf("uploading %s to %s: %w", name, issueID, err)
+	}
+	defer resp.Body.Close()
+
+	var attachments []model.Attachment
+	if err := json.NewDecoder(resp.Body).Decode(&attachments); err != nil {
+		return nil, fmt.Errorf("decoding attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// resolveURL resolves a server-relative URL (as returned in attachment
+// "url" fields, which include any context path) against the server origin.
+func (c *Client) resolveURL(ref string) (string, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(r).String(), nil
+}
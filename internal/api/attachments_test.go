@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func TestClient_ListAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1/attachments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"8-1","name":"crash.log","size":2048,"mimeType":"text/plain","url":"/api/files/8-1?sign=abc"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	attachments, err := client.ListAttachments(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attachments) != 1 {
+		t.Fatalf("got %d attachments, want 1", len(attachments))
+	}
+	if attachments[0].Name != "crash.log" || attachments[0].Size != 2048 {
+		t.Errorf("unexpected attachment: %+v", attachments[0])
+	}
+}
+
+func TestClient_DownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/youtrack/api/files/8-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("sign") != "abc" {
+			t.Errorf("signature not forwarded: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte("log contents"))
+	}))
+	defer server.Close()
+
+	// Attachment URLs include the server's context path, so they must be
+	// resolved against the origin rather than appended to the base URL.
+	client := NewClient(server.URL+"/youtrack", "test-token")
+	var buf bytes.Buffer
+	att := model.Attachment{Name: "crash.log", URL: "/youtrack/api/files/8-1?sign=abc"}
+	if err := client.DownloadAttachment(context.Background(), att, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "log contents" {
+		t.Errorf("got %q, want %q", buf.String(), "log contents")
+	}
+}
+
+func TestClient_DownloadAttachment_ForeignHostGetsNoToken(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("token leaked to foreign host: %q", auth)
+		}
+		w.Write([]byte("external"))
+	}))
+	defer foreign.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to server: %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	var buf bytes.Buffer
+	att := model.Attachment{Name: "crash.log", URL: foreign.URL + "/files/8-1"}
+	if err := client.DownloadAttachment(context.Background(), att, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "external" {
+		t.Errorf("got %q, want %q", buf.String(), "external")
+	}
+}
+
+func TestClient_DownloadAttachment_NoURL(t *testing.T) {
+	client := NewClient("http://example.invalid", "test-token")
+	if err := client.DownloadAttachment(context.Background(), model.Attachment{Name: "x"}, io.Discard); err == nil {
+		t.Fatal("expected error for attachment without URL")
+	}
+}
+
+func TestClient_UploadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/attachments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			t.Errorf("unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("reading form file: %v", err)
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if header.Filename != "screenshot.png" || string(data) != "PNGDATA" {
+			t.Errorf("unexpected upload: %s %q", header.Filename, data)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"8-2","name":"screenshot.png","size":7}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	attachments, err := client.UploadAttachment(context.Background(), "PROJ-1", "screenshot.png", strings.NewReader("PNGDATA"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].ID != "8-2" {
+		t.Errorf("unexpected attachments: %+v", attachments)
+	}
+}
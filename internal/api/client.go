@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +18,8 @@ type Client struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+	retry      RetryPolicy
+	onRetry    func(RetryEvent)
 }
 
 func NewClient(baseURL, token string) *Client {
@@ -25,51 +29,134 @@ func NewClient(baseURL, token string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retry: DefaultRetryPolicy(),
 	}
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
-	reqURL := c.baseURL + path
+// SetRetryPolicy replaces the policy used for retrying failed requests.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// SetRetryObserver registers fn to be called before every retry wait.
+// fn runs on the requesting goroutine and must not block for long.
+func (c *Client) SetRetryObserver(fn func(RetryEvent)) {
+	c.onRetry = fn
+}
+
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return c.doRequestURL(ctx, method, c.baseURL+path, "application/json", body)
+}
+
+// doRequestURL sends a request to an absolute URL with the given body
+// content type, retrying according to the client's policy.
+func (c *Client) doRequestURL(ctx context.Context, method, reqURL, contentType string, body io.Reader) (*http.Response, error) {
+	path := strings.TrimPrefix(reqURL, c.baseURL)
+
+	// Buffer the body so it can be replayed on retry.
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+	}
+
+	maxAttempts := c.retry.attempts(method)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, reqURL, contentType, payload)
+		if attempt >= maxAttempts || !shouldRetry(ctx, method, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return checkResponse(resp)
+		}
+
+		event := RetryEvent{
+			Method:      method,
+			Path:        path,
+			Attempt:     attempt,
+			MaxAttempts: maxAttempts,
+			Wait:        c.retry.backoff(attempt, parseRetryAfter(resp, time.Now())),
+			Err:         err,
+		}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if c.onRetry != nil {
+			c.onRetry(event)
+		}
+		if err := sleepContext(ctx, event.Wait); err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+	}
+}
 
-	req, err := http.NewRequest(method, reqURL, body)
+// send performs a single HTTP attempt.
+func (c *Client) send(ctx context.Context, method, reqURL, contentType string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	// Never hand the token to a host other than the configured server, e.g.
+	// when an attachment URL points to external storage.
+	if c.sameOrigin(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
+	return resp, nil
+}
+
+// sameOrigin reports whether u has the scheme and host of the server base URL.
+func (c *Client) sameOrigin(u *url.URL) bool {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
 
+// checkResponse converts HTTP error statuses into *Error, closing the body.
+func checkResponse(resp *http.Response) (*http.Response, error) {
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(respBody))
+		return nil, newError(resp.StatusCode, respBody)
 	}
 
 	return resp, nil
 }
 
-func (c *Client) get(path string, params url.Values) (*http.Response, error) {
+func (c *Client) get(ctx context.Context, path string, params url.Values) (*http.Response, error) {
 	if len(params) > 0 {
 		path = path + "?" + params.Encode()
 	}
-	return c.doRequest(http.MethodGet, path, nil)
+	return c.doRequest(ctx, http.MethodGet, path, nil)
 }
 
-func (c *Client) post(path string, body io.Reader) (*http.Response, error) {
-	return c.doRequest(http.MethodPost, path, body)
+func (c *Client) post(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	return c.doRequest(ctx, http.MethodPost, path, body)
 }
 
 // doDelete performs a DELETE and closes the response body (DELETE returns no useful body).
-func (c *Client) doDelete(path string) error {
-	resp, err := c.doRequest(http.MethodDelete, path, nil)
+func (c *Client) doDelete(ctx context.Context, path string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
 	}
@@ -77,11 +164,11 @@ func (c *Client) doDelete(path string) error {
 	return nil
 }
 
-func (c *Client) GetCurrentUser() (*model.User, error) {
+func (c *Client) GetCurrentUser(ctx context.Context) (*model.User, error) {
 	params := url.Values{}
 	params.Set("fields", "id,login,fullName")
 
-	resp, err := c.get("/api/users/me", params)
+	resp, err := c.get(ctx, "/api/users/me", params)
 	if err != nil {
 		return nil, fmt.Errorf("fetching current user: %w", err)
 	}
@@ -94,3 +181,13 @@ func (c *Client) GetCurrentUser() (*model.User, error) {
 
 	return &user, nil
 }
+
+// IssueURL returns the web UI address of an issue, or of one of its comments
+// when commentID is set.
+func (c *Client) IssueURL(issueID, commentID string) string {
+	u := c.baseURL + "/issue/" + url.PathEscape(issueID)
+	if commentID != "" {
+		u += "#focus=Comments-" + url.PathEscape(commentID) + ".0-0"
+	}
+	return u
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +26,7 @@ func TestClient_GetCurrentUser(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	user, err := client.GetCurrentUser()
+	user, err := client.GetCurrentUser(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -45,7 +47,7 @@ func TestClient_GetCurrentUser_AuthError(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "bad-token")
-	_, err := client.GetCurrentUser()
+	_, err := client.GetCurrentUser(context.Background())
 	if err == nil {
 		t.Fatal("expected error for unauthorized request")
 	}
@@ -59,7 +61,7 @@ func TestClient_GetCurrentUser_Timeout(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	user, err := client.GetCurrentUser()
+	user, err := client.GetCurrentUser(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -67,3 +69,33 @@ func TestClient_GetCurrentUser_Timeout(t *testing.T) {
 		t.Errorf("got login %q, want %q", user.Login, "john")
 	}
 }
+
+func TestClient_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.GetIssue(ctx, "PROJ-1")
+	if err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_IssueURL(t *testing.T) {
+	client := NewClient("https://yt.example.com/", "test-token")
+
+	if got, want := client.IssueURL("PROJ-1", ""), "https://yt.example.com/issue/PROJ-1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := client.IssueURL("PROJ-1", "4-2"), "https://yt.example.com/issue/PROJ-1#focus=Comments-4-2.0-0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -11,11 +12,11 @@ import (
 
 const commentFields = "id,text,author(login,fullName),created,updated"
 
-func (c *Client) ListComments(issueID string) ([]model.Comment, error) {
+func (c *Client) ListComments(ctx context.Context, issueID string) ([]model.Comment, error) {
 	params := url.Values{}
 	params.Set("fields", commentFields)
 
-	resp, err := c.get("/api/issues/"+url.PathEscape(issueID)+"/comments", params)
+	resp, err := c.get(ctx, "/api/issues/"+url.PathEscape(issueID)+"/comments", params)
 	if err != nil {
 		return nil, fmt.Errorf("listing comments for %s: %w", issueID, err)
 	}
@@ -29,7 +30,7 @@ func (c *Client) ListComments(issueID string) ([]model.Comment, error) {
 	return comments, nil
 }
 
-func (c *Client) AddComment(issueID, text string) (*model.Comment, error) {
+func (c *Client) AddComment(ctx context.Context, issueID, text string) (*model.Comment, error) {
 	payload := map[string]string{"text": text}
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -39,7 +40,7 @@ func (c *Client) AddComment(issueID, text string) (*model.Comment, error) {
 	params := url.Values{}
 	params.Set("fields", commentFields)
 
-	resp, err := c.post("/api/issues/"+url.PathEscape(issueID)+"/comments?"+params.Encode(), bytes.NewReader(body))
+	resp, err := c.post(ctx, "/api/issues/"+url.PathEscape(issueID)+"/comments?"+params.Encode(), bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("adding comment to %s: %w", issueID, err)
 	}
@@ -52,3 +53,40 @@ func (c *Client) AddComment(issueID, text string) (*model.Comment, error) {
 
 	return &comment, nil
 }
+
+// UpdateComment replaces the text of an existing comment.
+func (c *Client) UpdateComment(ctx context.Context, issueID, commentID, text string) (*model.Comment, error) {
+	payload := map[string]string{"text": text}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling comment: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", commentFields)
+
+	resp, err := c.post(ctx, commentPath(issueID, commentID)+"?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("updating comment on %s: %w", issueID, err)
+	}
+	defer resp.Body.Close()
+
+	var comment model.Comment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("decoding comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// DeleteComment permanently removes a comment from the issue.
+func (c *Client) DeleteComment(ctx context.Context, issueID, commentID string) error {
+	if err := c.doDelete(ctx, commentPath(issueID, commentID)); err != nil {
+		return fmt.Errorf("deleting comment on %s: %w", issueID, err)
+	}
+	return nil
+}
+
+func commentPath(issueID, commentID string) string {
+	return "/api/issues/" + url.PathEscape(issueID) + "/comments/" + url.PathEscape(commentID)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -19,7 +20,7 @@ func TestClient_ListComments(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	comments, err := client.ListComments("PROJ-1")
+	comments, err := client.ListComments(context.Background(), "PROJ-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -53,7 +54,7 @@ func TestClient_AddComment(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	comment, err := client.AddComment("PROJ-1", "New comment")
+	comment, err := client.AddComment(context.Background(), "PROJ-1", "New comment")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -61,3 +62,51 @@ func TestClient_AddComment(t *testing.T) {
 		t.Errorf("got text %q, want %q", comment.Text, "New comment")
 	}
 }
+
+func TestClient_UpdateComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/comments/4-2" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+		if payload["text"] != "Fixed typo" {
+			t.Errorf("unexpected text: %v", payload["text"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"4-2","text":"Fixed typo","created":1700100000000,"updated":1700200000000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	comment, err := client.UpdateComment(context.Background(), "PROJ-1", "4-2", "Fixed typo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.Text != "Fixed typo" || comment.Updated == nil {
+		t.Errorf("unexpected comment: %+v", comment)
+	}
+}
+
+func TestClient_DeleteComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/comments/4-2" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.DeleteComment(context.Background(), "PROJ-1", "4-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -8,11 +9,11 @@ import (
 	"github.com/cf/lazytrack/internal/model"
 )
 
-func (c *Client) ListProjectCustomFields(projectID string) ([]model.ProjectCustomField, error) {
+func (c *Client) ListProjectCustomFields(ctx context.Context, projectID string) ([]model.ProjectCustomField, error) {
 	params := url.Values{}
-	params.Set("fields", "field(id,name,$type),bundle(values(id,name,$type))")
+	params.Set("fields", "field(id,name,$type,fieldType(id)),bundle(values(id,name,$type))")
 
-	resp, err := c.get("/api/admin/projects/"+url.PathEscape(projectID)+"/customFields", params)
+	resp, err := c.get(ctx, "/api/admin/projects/"+url.PathEscape(projectID)+"/customFields", params)
 	if err != nil {
 		return nil, fmt.Errorf("listing custom fields for project %s: %w", projectID, err)
 	}
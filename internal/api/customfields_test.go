@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -25,7 +26,7 @@ func TestClient_ListProjectCustomFields(t *testing.T) {
 				]}
 			},
 			{
-				"field": {"id":"f-2","name":"Type","$type":"SingleEnumIssueCustomField"},
+				"field": {"id":"f-2","name":"Type","$type":"SingleEnumIssueCustomField","fieldType":{"id":"enum[1]"}},
 				"bundle": {"values":[
 					{"id":"v-4","name":"Bug","$type":"EnumBundleElement"},
 					{"id":"v-5","name":"Task","$type":"EnumBundleElement"},
@@ -37,7 +38,7 @@ func TestClient_ListProjectCustomFields(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	fields, err := client.ListProjectCustomFields("0-0")
+	fields, err := client.ListProjectCustomFields(context.Background(), "0-0")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -67,6 +68,9 @@ func TestClient_ListProjectCustomFields(t *testing.T) {
 	if len(fields[1].Bundle.Values) != 3 {
 		t.Fatalf("got %d type values, want 3", len(fields[1].Bundle.Values))
 	}
+	if fields[1].Field.FieldType.ID != "enum[1]" {
+		t.Errorf("got field type id %q, want %q", fields[1].Field.FieldType.ID, "enum[1]")
+	}
 }
 
 func TestClient_ListProjectCustomFields_Empty(t *testing.T) {
@@ -77,7 +81,7 @@ func TestClient_ListProjectCustomFields_Empty(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	fields, err := client.ListProjectCustomFields("0-0")
+	fields, err := client.ListProjectCustomFields(context.Background(), "0-0")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
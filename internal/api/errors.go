@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error is an HTTP 4xx/5xx response from the YouTrack REST API.
+type Error struct {
+	StatusCode  int
+	Code        string // YouTrack "error" field, e.g. "Not Found"
+	Description string // YouTrack "error_description" field
+	Body        string // raw body when it is not YouTrack's JSON error shape
+}
+
+// newError builds an Error from a response status and body, decoding
+// YouTrack's {"error": ..., "error_description": ...} payload when present.
+func newError(status int, body []byte) *Error {
+	e := &Error{StatusCode: status}
+	var payload struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && (payload.Error != "" || payload.Description != "") {
+		e.Code = payload.Error
+		e.Description = payload.Description
+	} else {
+		e.Body = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+// Message returns the most specific human-readable text the server sent,
+// or "" when it sent nothing useful.
+func (e *Error) Message() string {
+	switch {
+	case e.Description != "":
+		return e.Description
+	case e.Code != "":
+		return e.Code
+	default:
+		return e.Body
+	}
+}
+
+func (e *Error) Error() string {
+	if msg := e.Message(); msg != "" {
+		return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, msg)
+	}
+	return fmt.Sprintf("API error (HTTP %d)", e.StatusCode)
+}
+
+// AsError returns the *Error wrapped in err, if any.
+func AsError(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, status int) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.StatusCode == status
+}
+
+// IsNotFound reports whether err is an HTTP 404 from the API.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an HTTP 401 (missing, expired or revoked token).
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an HTTP 403 (token lacks permission).
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
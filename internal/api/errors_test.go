@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ErrorIsTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Not Found","error_description":"Entity with id PROJ-123 not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.GetIssue(context.Background(), "PROJ-123")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	apiErr, ok := AsError(err)
+	if !ok {
+		t.Fatalf("expected *Error in chain, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", apiErr.StatusCode)
+	}
+	if apiErr.Code != "Not Found" {
+		t.Errorf("got code %q, want %q", apiErr.Code, "Not Found")
+	}
+	if apiErr.Description != "Entity with id PROJ-123 not found" {
+		t.Errorf("got description %q", apiErr.Description)
+	}
+	if !IsNotFound(err) {
+		t.Error("IsNotFound should be true")
+	}
+	if IsUnauthorized(err) || IsForbidden(err) {
+		t.Error("only IsNotFound should match a 404")
+	}
+}
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantMsg string
+		wantErr string
+	}{
+		{
+			name:    "youtrack json",
+			status:  400,
+			body:    `{"error":"bad_request","error_description":"Summary is required"}`,
+			wantMsg: "Summary is required",
+			wantErr: "API error (HTTP 400): Summary is required",
+		},
+		{
+			name:    "code only",
+			status:  401,
+			body:    `{"error":"Unauthorized"}`,
+			wantMsg: "Unauthorized",
+			wantErr: "API error (HTTP 401): Unauthorized",
+		},
+		{
+			name:    "plain text body",
+			status:  502,
+			body:    "Bad Gateway\n",
+			wantMsg: "Bad Gateway",
+			wantErr: "API error (HTTP 502): Bad Gateway",
+		},
+		{
+			name:    "empty body",
+			status:  503,
+			body:    "",
+			wantMsg: "",
+			wantErr: "API error (HTTP 503)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newError(tt.status, []byte(tt.body))
+			if e.Message() != tt.wantMsg {
+				t.Errorf("Message() = %q, want %q", e.Message(), tt.wantMsg)
+			}
+			if e.Error() != tt.wantErr {
+				t.Errorf("Error() = %q, want %q", e.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatusChecks_Wrapped(t *testing.T) {
+	wrap := func(status int) error {
+		return fmt.Errorf("fetching current user: %w", newError(status, nil))
+	}
+	if !IsUnauthorized(wrap(401)) {
+		t.Error("IsUnauthorized should see through wrapping")
+	}
+	if !IsForbidden(wrap(403)) {
+		t.Error("IsForbidden should see through wrapping")
+	}
+	if IsNotFound(fmt.Errorf("plain error")) {
+		t.Error("IsNotFound should be false for non-API errors")
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -10,11 +11,11 @@ import (
 	"github.com/cf/lazytrack/internal/model"
 )
 
-const issueListFields = "id,idReadable,summary,description,created,updated,resolved,reporter(login,fullName),project(id,name,shortName),customFields(id,name,$type,value(id,name,login,fullName))"
+const issueListFields = "id,idReadable,summary,description,created,updated,resolved,reporter(login,fullName),project(id,name,shortName),customFields(id,name,$type,value(id,name,login,fullName,minutes,presentation,text)),parent(issues(idReadable)),tags(" + tagFields + ")"
 
-const issueDetailFields = issueListFields + ",comments(id,text,author(login,fullName),created,updated)"
+const issueDetailFields = issueListFields + ",comments(id,text,author(login,fullName),created,updated),attachments(" + attachmentFields + "),links(" + linkFields + ")"
 
-func (c *Client) ListIssues(query string, skip, top int) ([]model.Issue, error) {
+func (c *Client) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
 	params := url.Values{}
 	params.Set("fields", issueListFields)
 	params.Set("$skip", strconv.Itoa(skip))
@@ -23,7 +24,7 @@ func (c *Client) ListIssues(query string, skip, top int) ([]model.Issue, error)
 		params.Set("query", query)
 	}
 
-	resp, err := c.get("/api/issues", params)
+	resp, err := c.get(ctx, "/api/issues", params)
 	if err != nil {
 		return nil, fmt.Errorf("listing issues: %w", err)
 	}
@@ -37,11 +38,42 @@ func (c *Client) ListIssues(query string, skip, top int) ([]model.Issue, error)
 	return issues, nil
 }
 
-func (c *Client) GetIssue(issueID string) (*model.Issue, error) {
+// ListIssueUpdateTimes returns when each of the first top issues matching
+// query was last updated, in Unix milliseconds. Only that field is fetched,
+// so it is a cheap way to count matches.
+func (c *Client) ListIssueUpdateTimes(ctx context.Context, query string, top int) ([]int64, error) {
+	params := url.Values{}
+	params.Set("fields", "updated")
+	params.Set("$top", strconv.Itoa(top))
+	if query != "" {
+		params.Set("query", query)
+	}
+
+	resp, err := c.get(ctx, "/api/issues", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issues []struct {
+		Updated int64 `json:"updated"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("decoding issues: %w", err)
+	}
+
+	times := make([]int64, len(issues))
+	for i, issue := range issues {
+		times[i] = issue.Updated
+	}
+	return times, nil
+}
+
+func (c *Client) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
 	params := url.Values{}
 	params.Set("fields", issueDetailFields)
 
-	resp, err := c.get("/api/issues/"+url.PathEscape(issueID), params)
+	resp, err := c.get(ctx, "/api/issues/"+url.PathEscape(issueID), params)
 	if err != nil {
 		return nil, fmt.Errorf("getting issue %s: %w", issueID, err)
 	}
@@ -55,7 +87,7 @@ func (c *Client) GetIssue(issueID string) (*model.Issue, error) {
 	return &issue, nil
 }
 
-func (c *Client) CreateIssue(projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
+func (c *Client) CreateIssue(ctx context.Context, projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
 	payload := map[string]any{
 		"project":     map[string]string{"id": projectID},
 		"summary":     summary,
@@ -73,7 +105,7 @@ func (c *Client) CreateIssue(projectID, summary, description string, customField
 	params := url.Values{}
 	params.Set("fields", issueListFields)
 
-	resp, err := c.post("/api/issues?"+params.Encode(), bytes.NewReader(body))
+	resp, err := c.post(ctx, "/api/issues?"+params.Encode(), bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating issue: %w", err)
 	}
@@ -87,7 +119,7 @@ func (c *Client) CreateIssue(projectID, summary, description string, customField
 	return &issue, nil
 }
 
-func (c *Client) UpdateIssue(issueID string, fields map[string]any) error {
+func (c *Client) UpdateIssue(ctx context.Context, issueID string, fields map[string]any) error {
 	body, err := json.Marshal(fields)
 	if err != nil {
 		return fmt.Errorf("marshaling update: %w", err)
@@ -96,7 +128,7 @@ func (c *Client) UpdateIssue(issueID string, fields map[string]any) error {
 	params := url.Values{}
 	params.Set("fields", "id,idReadable")
 
-	resp, err := c.post("/api/issues/"+url.PathEscape(issueID)+"?"+params.Encode(), bytes.NewReader(body))
+	resp, err := c.post(ctx, "/api/issues/"+url.PathEscape(issueID)+"?"+params.Encode(), bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("updating issue %s: %w", issueID, err)
 	}
@@ -105,18 +137,18 @@ func (c *Client) UpdateIssue(issueID string, fields map[string]any) error {
 	return nil
 }
 
-func (c *Client) DeleteIssue(issueID string) error {
-	if err := c.doDelete("/api/issues/" + url.PathEscape(issueID)); err != nil {
+func (c *Client) DeleteIssue(ctx context.Context, issueID string) error {
+	if err := c.doDelete(ctx, "/api/issues/"+url.PathEscape(issueID)); err != nil {
 		return fmt.Errorf("deleting issue %s: %w", issueID, err)
 	}
 	return nil
 }
 
-func (c *Client) ListProjects() ([]model.Project, error) {
+func (c *Client) ListProjects(ctx context.Context) ([]model.Project, error) {
 	params := url.Values{}
 	params.Set("fields", "id,name,shortName")
 
-	resp, err := c.get("/api/admin/projects", params)
+	resp, err := c.get(ctx, "/api/admin/projects", params)
 	if err != nil {
 		return nil, fmt.Errorf("listing projects: %w", err)
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -26,7 +27,7 @@ func TestClient_ListIssues(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	issues, err := client.ListIssues("", 0, 50)
+	issues, err := client.ListIssues(context.Background(), "", 0, 50)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -53,7 +54,7 @@ func TestClient_ListIssues_WithQuery(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	issues, err := client.ListIssues("project: PROJ #Unresolved", 0, 50)
+	issues, err := client.ListIssues(context.Background(), "project: PROJ #Unresolved", 0, 50)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -62,6 +63,27 @@ func TestClient_ListIssues_WithQuery(t *testing.T) {
 	}
 }
 
+func TestClient_ListIssueUpdateTimes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("fields") != "updated" || q.Get("$top") != "101" || q.Get("query") != "Type: Bug" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"updated":2000,"$type":"Issue"},{"updated":500,"$type":"Issue"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	times, err := client.ListIssueUpdateTimes(context.Background(), "Type: Bug", 101)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(times) != 2 || times[0] != 2000 || times[1] != 500 {
+		t.Errorf("got %v, want [2000 500]", times)
+	}
+}
+
 func TestClient_ListIssues_Pagination(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Query().Get("$skip") != "50" {
@@ -76,7 +98,7 @@ func TestClient_ListIssues_Pagination(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	issues, err := client.ListIssues("", 50, 50)
+	issues, err := client.ListIssues(context.Background(), "", 50, 50)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -99,7 +121,7 @@ func TestClient_GetIssue(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	issue, err := client.GetIssue("PROJ-1")
+	issue, err := client.GetIssue(context.Background(), "PROJ-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -134,7 +156,7 @@ func TestClient_CreateIssue(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	issue, err := client.CreateIssue("0-0", "New issue", "Description here", nil)
+	issue, err := client.CreateIssue(context.Background(), "0-0", "New issue", "Description here", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -175,7 +197,7 @@ func TestClient_CreateIssue_WithCustomFields(t *testing.T) {
 			"value": map[string]string{"name": "Bug", "$type": "EnumBundleElement"},
 		},
 	}
-	issue, err := client.CreateIssue("0-0", "With fields", "Desc", customFields)
+	issue, err := client.CreateIssue(context.Background(), "0-0", "With fields", "Desc", customFields)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -198,7 +220,7 @@ func TestClient_UpdateIssue(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	err := client.UpdateIssue("PROJ-1", map[string]any{"summary": "Updated"})
+	err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"summary": "Updated"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -217,7 +239,7 @@ func TestClient_DeleteIssue(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	err := client.DeleteIssue("PROJ-1")
+	err := client.DeleteIssue(context.Background(), "PROJ-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -234,7 +256,7 @@ func TestClient_ListProjects(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	projects, err := client.ListProjects()
+	projects, err := client.ListProjects(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const linkTypeFields = "id,name,sourceToTarget,targetToSource,directed"
+
+const linkFields = "id,direction,linkType(" + linkTypeFields + "),issues(id,idReadable,summary,resolved)"
+
+func (c *Client) ListLinkTypes(ctx context.Context) ([]model.IssueLinkType, error) {
+	params := url.Values{}
+	params.Set("fields", linkTypeFields)
+
+	resp, err := c.get(ctx, "/api/issueLinkTypes", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing link types: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var types []model.IssueLinkType
+	if err := json.NewDecoder(resp.Body).Decode(&types); err != nil {
+		return nil, fmt.Errorf("decoding link types: %w", err)
+	}
+
+	return types, nil
+}
+
+// AddIssueLink links targetID to issueID. linkID selects the link type and
+// direction (see model.IssueLinkType.Directions).
+func (c *Client) AddIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	payload := map[string]string{"idReadable": targetID}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling link: %w", err)
+	}
+
+	resp, err := c.post(ctx, linkIssuesPath(issueID, linkID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("linking %s to %s: %w", targetID, issueID, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveIssueLink removes targetID from the given link of issueID.
+func (c *Client) RemoveIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	if err := c.doDelete(ctx, linkIssuesPath(issueID, linkID)+"/"+url.PathEscape(targetID)); err != nil {
+		return fmt.Errorf("unlinking %s from %s: %w", targetID, issueID, err)
+	}
+	return nil
+}
+
+func linkIssuesPath(issueID, linkID string) string {
+	return "/api/issues/" + url.PathEscape(issueID) + "/links/" + url.PathEscape(linkID) + "/issues"
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListLinkTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issueLinkTypes" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"74-0","name":"Depend","sourceToTarget":"depends on","targetToSource":"is required for","directed":true}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	types, err := client.ListLinkTypes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != 1 || !types[0].Directed || types[0].TargetToSource != "is required for" {
+		t.Errorf("unexpected link types: %+v", types)
+	}
+}
+
+func TestClient_GetIssue_Links(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"idReadable":"PROJ-1","links":[{"id":"74-0s","direction":"OUTWARD","linkType":{"id":"74-0","name":"Depend","sourceToTarget":"depends on","directed":true},"issues":[{"id":"2-9","idReadable":"PROJ-9","summary":"Schema"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	issue, err := client.GetIssue(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issue.Links) != 1 || len(issue.Links[0].Issues) != 1 {
+		t.Fatalf("unexpected links: %+v", issue.Links)
+	}
+	if issue.Links[0].Phrase() != "depends on" || issue.Links[0].Issues[0].IDReadable != "PROJ-9" {
+		t.Errorf("unexpected link: %+v", issue.Links[0])
+	}
+}
+
+func TestClient_AddIssueLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/links/74-0s/issues" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+		if payload["idReadable"] != "PROJ-9" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddIssueLink(context.Background(), "PROJ-1", "74-0s", "PROJ-9"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_RemoveIssueLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/links/74-0s/issues/PROJ-9" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.RemoveIssueLink(context.Background(), "PROJ-1", "74-0s", "PROJ-9"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doRequest retries transport errors, HTTP 429
+// and HTTP 5xx responses. Writes are only retried on 429 and 503, where the
+// server declined the request before applying it.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // wait before the first retry, doubled on each further one
+	MaxDelay    time.Duration // upper bound for any single wait, including Retry-After
+	Methods     []string      // HTTP methods eligible for retry
+}
+
+// DefaultRetryPolicy retries idempotent GETs up to three attempts in total.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Methods:     []string{http.MethodGet},
+	}
+}
+
+// RetryEvent describes a failed attempt that is about to be retried.
+type RetryEvent struct {
+	Method      string
+	Path        string
+	Attempt     int           // attempts made so far, starting at 1
+	MaxAttempts int           // total attempts the policy allows
+	Wait        time.Duration // delay before the next attempt
+	StatusCode  int           // HTTP status of the failed attempt, 0 for transport errors
+	Err         error         // transport error of the failed attempt, nil for HTTP errors
+}
+
+// Reason returns a short description of why the attempt failed.
+func (e RetryEvent) Reason() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("HTTP %d", e.StatusCode)
+	}
+	return "network error"
+}
+
+// attempts returns how many attempts the policy allows for the given method.
+func (p RetryPolicy) attempts(method string) int {
+	if p.MaxAttempts <= 1 || !slices.Contains(p.Methods, method) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the wait before retrying after the given (1-based) failed attempt.
+// A server-provided Retry-After wins over the exponential schedule; both are
+// capped at MaxDelay. The exponential delay uses equal jitter: half fixed,
+// half random, so concurrent clients don't retry in lockstep.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return p.capDelay(retryAfter)
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	d = p.capDelay(d)
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+func (p RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// shouldRetry reports whether a response or transport error is worth another attempt.
+// For non-idempotent methods a timeout or generic 5xx may mean the server
+// already applied the write, so only explicit "not processed" statuses qualify.
+func shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if !idempotent(method) {
+		return err == nil && (resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusServiceUnavailable)
+	}
+	if err != nil {
+		// Only transport failures (*url.Error) are transient; request
+		// construction errors would fail the same way every time.
+		var urlErr *url.Error
+		return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// idempotent reports whether repeating a request with the given method has
+// the same effect as sending it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter decodes a Retry-After header given either in seconds or as
+// an HTTP date. Returns 0 when the header is absent or unparseable.
+func parseRetryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
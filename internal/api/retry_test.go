@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 5 * time.Millisecond
+	return p
+}
+
+func TestClient_RetriesGetOn503(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1-1","login":"john","fullName":"John Doe"}`))
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(fastRetryPolicy())
+	client.SetRetryObserver(func(ev RetryEvent) { events = append(events, ev) })
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Login != "john" {
+		t.Errorf("got login %q, want %q", user.Login, "john")
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d retry events, want 2", len(events))
+	}
+	if events[1].Attempt != 2 || events[1].MaxAttempts != 3 {
+		t.Errorf("got attempt %d/%d, want 2/3", events[1].Attempt, events[1].MaxAttempts)
+	}
+	if events[0].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want 503", events[0].StatusCode)
+	}
+	if events[0].Reason() != "HTTP 503" {
+		t.Errorf("got reason %q, want %q", events[0].Reason(), "HTTP 503")
+	}
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := client.ListIssues(context.Background(), "", 0, 50)
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+}
+
+func TestClient_DoesNotRetryPostByDefault(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	if err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"summary": "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1 (POST is not idempotent)", calls)
+	}
+}
+
+func TestClient_RetriesPostWhenAllowed_ReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(buf))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"2-1","idReadable":"PROJ-1"}`))
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.Methods = []string{http.MethodGet, http.MethodPost}
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(policy)
+
+	if err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"summary": "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("got %d calls, want 2", len(bodies))
+	}
+	if bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("body not replayed: %q vs %q", bodies[0], bodies[1])
+	}
+}
+
+func TestClient_DoesNotRetryPostOnAmbiguousFailure(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.Methods = []string{http.MethodGet, http.MethodPost}
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(policy)
+
+	if err := client.UpdateIssue(context.Background(), "PROJ-1", map[string]any{"summary": "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1 (a 502 may hide an applied write)", calls)
+	}
+}
+
+func TestClient_DoesNotRetry4xx(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	if _, err := client.GetIssue(context.Background(), "PROJ-404"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestClient_RetryWaitStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := DefaultRetryPolicy()
+	policy.MaxDelay = time.Minute
+	client := NewClient(server.URL, "test-token")
+	client.SetRetryPolicy(policy)
+	client.SetRetryObserver(func(RetryEvent) { cancel() })
+
+	start := time.Now()
+	if _, err := client.GetIssue(ctx, "PROJ-1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("retry wait was not interrupted by cancellation")
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 5: time.Second} {
+		for range 20 {
+			got := p.backoff(attempt, 0)
+			if got < want/2 || got > want {
+				t.Errorf("backoff(%d) = %v, want within [%v, %v]", attempt, got, want/2, want)
+			}
+		}
+	}
+
+	if got := p.backoff(1, 700*time.Millisecond); got != 700*time.Millisecond {
+		t.Errorf("Retry-After not honored: got %v", got)
+	}
+	if got := p.backoff(1, time.Hour); got != time.Second {
+		t.Errorf("Retry-After not capped: got %v", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"absent", "", 0},
+		{"seconds", "5", 5 * time.Second},
+		{"negative", "-1", 0},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			if got := parseRetryAfter(resp, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
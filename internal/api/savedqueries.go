@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const savedQueryFields = "id,name,query"
+
+// ListSavedQueries returns the saved searches visible to the current user.
+func (c *Client) ListSavedQueries(ctx context.Context) ([]model.SavedQuery, error) {
+	params := url.Values{}
+	params.Set("fields", savedQueryFields)
+	params.Set("$top", "-1")
+
+	resp, err := c.get(ctx, "/api/savedQueries", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing saved searches: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var queries []model.SavedQuery
+	if err := json.NewDecoder(resp.Body).Decode(&queries); err != nil {
+		return nil, fmt.Errorf("decoding saved searches: %w", err)
+	}
+
+	return queries, nil
+}
+
+// CreateSavedQuery saves a search on the server under the given name.
+func (c *Client) CreateSavedQuery(ctx context.Context, name, query string) (*model.SavedQuery, error) {
+	payload := map[string]string{"name": name, "query": query}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling saved search: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", savedQueryFields)
+	resp, err := c.post(ctx, "/api/savedQueries?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("saving search %q: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	var saved model.SavedQuery
+	if err := json.NewDecoder(resp.Body).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("decoding saved search: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// UpdateSavedQuery changes the query of an existing saved search.
+func (c *Client) UpdateSavedQuery(ctx context.Context, id, query string) (*model.SavedQuery, error) {
+	payload := map[string]string{"query": query}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling saved search: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", savedQueryFields)
+	resp, err := c.post(ctx, "/api/savedQueries/"+url.PathEscape(id)+"?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("updating saved search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var saved model.SavedQuery
+	if err := json.NewDecoder(resp.Body).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("decoding saved search: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// DeleteSavedQuery deletes a saved search from the server.
+func (c *Client) DeleteSavedQuery(ctx context.Context, id string) error {
+	if err := c.doDelete(ctx, "/api/savedQueries/"+url.PathEscape(id)); err != nil {
+		return fmt.Errorf("deleting saved search: %w", err)
+	}
+	return nil
+}
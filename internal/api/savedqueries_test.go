@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListSavedQueries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/savedQueries" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("fields") != savedQueryFields {
+			t.Errorf("unexpected fields: %s", r.URL.Query().Get("fields"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"117-1","name":"My open","query":"for: me #Unresolved"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	queries, err := client.ListSavedQueries(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 1 || queries[0].Name != "My open" || queries[0].Query != "for: me #Unresolved" {
+		t.Errorf("unexpected saved searches: %+v", queries)
+	}
+}
+
+func TestClient_CreateSavedQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/savedQueries" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "Bugs" || body["query"] != "Type: Bug" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"117-2","name":"Bugs","query":"Type: Bug"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	saved, err := client.CreateSavedQuery(context.Background(), "Bugs", "Type: Bug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.ID != "117-2" {
+		t.Errorf("unexpected saved search: %+v", saved)
+	}
+}
+
+func TestClient_UpdateSavedQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/savedQueries/117-2" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["query"] != "Type: Bug #Unresolved" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"117-2","name":"Bugs","query":"Type: Bug #Unresolved"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	saved, err := client.UpdateSavedQuery(context.Background(), "117-2", "Type: Bug #Unresolved")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.Query != "Type: Bug #Unresolved" {
+		t.Errorf("unexpected saved search: %+v", saved)
+	}
+}
+
+func TestClient_DeleteSavedQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/savedQueries/117-2" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.DeleteSavedQuery(context.Background(), "117-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const searchAssistFields = "query,caret,suggestions(option,prefix,suffix,description,completionStart,completionEnd,matchingStart,matchingEnd,caret),styleRanges(start,length,style)"
+
+// SearchAssist asks the server for completions of a search query at the
+// given caret position, and for the parts of the query it can't parse.
+func (c *Client) SearchAssist(ctx context.Context, query string, caret int) (*model.SearchAssist, error) {
+	payload := map[string]any{"query": query, "caret": caret}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling query: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", searchAssistFields)
+	resp, err := c.post(ctx, "/api/search/assist?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("completing query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var assist model.SearchAssist
+	if err := json.NewDecoder(resp.Body).Decode(&assist); err != nil {
+		return nil, fmt.Errorf("decoding query completions: %w", err)
+	}
+
+	return &assist, nil
+}
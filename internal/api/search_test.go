@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SearchAssist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/search/assist" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("fields") != searchAssistFields {
+			t.Errorf("unexpected fields: %s", r.URL.Query().Get("fields"))
+		}
+		var body struct {
+			Query string `json:"query"`
+			Caret int    `json:"caret"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Query != "sta" || body.Caret != 3 {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"query":"sta","caret":3,
+			"suggestions":[{"option":"State","suffix":": ","description":"field","completionStart":0,"completionEnd":3,"matchingStart":0,"matchingEnd":3,"caret":7}],
+			"styleRanges":[{"start":0,"length":3,"style":"error"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	assist, err := client.SearchAssist(context.Background(), "sta", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assist.Suggestions) != 1 || assist.Suggestions[0].Option != "State" || assist.Suggestions[0].Caret != 7 {
+		t.Errorf("unexpected suggestions: %+v", assist.Suggestions)
+	}
+	if errs := assist.Errors(); len(errs) != 1 || errs[0].Length != 3 {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const tagFields = "id,name,color(background,foreground)"
+
+// ListTags returns the tags visible to the current user.
+func (c *Client) ListTags(ctx context.Context) ([]model.Tag, error) {
+	params := url.Values{}
+	params.Set("fields", tagFields)
+	params.Set("$top", "-1")
+
+	resp, err := c.get(ctx, "/api/tags", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tags []model.Tag
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("decoding tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// AddIssueTag applies an existing tag to the issue.
+func (c *Client) AddIssueTag(ctx context.Context, issueID, tagID string) error {
+	payload := map[string]string{"id": tagID}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling tag: %w", err)
+	}
+
+	resp, err := c.post(ctx, issueTagsPath(issueID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("tagging %s: %w", issueID, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveIssueTag removes the tag from the issue. The tag itself is kept.
+func (c *Client) RemoveIssueTag(ctx context.Context, issueID, tagID string) error {
+	if err := c.doDelete(ctx, issueTagsPath(issueID)+"/"+url.PathEscape(tagID)); err != nil {
+		return fmt.Errorf("untagging %s: %w", issueID, err)
+	}
+	return nil
+}
+
+func issueTagsPath(issueID string) string {
+	return "/api/issues/" + url.PathEscape(issueID) + "/tags"
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("fields") != tagFields {
+			t.Errorf("unexpected fields: %s", r.URL.Query().Get("fields"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"6-1","name":"regression","color":{"background":"#fce5f1","foreground":"#b8256e"}},{"id":"6-2","name":"triage","color":null}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	tags, err := client.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("got %d tags, want 2", len(tags))
+	}
+	if tags[0].Name != "regression" || tags[0].Color == nil || tags[0].Color.Background != "#fce5f1" {
+		t.Errorf("unexpected tag: %+v", tags[0])
+	}
+	if tags[1].Color != nil {
+		t.Errorf("expected no color, got %+v", tags[1].Color)
+	}
+}
+
+func TestClient_GetIssue_Tags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"idReadable":"PROJ-1","tags":[{"id":"6-1","name":"regression"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	issue, err := client.GetIssue(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issue.Tags) != 1 || issue.Tags[0].Name != "regression" {
+		t.Errorf("unexpected tags: %+v", issue.Tags)
+	}
+}
+
+func TestClient_AddIssueTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/tags" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		json.Unmarshal(body, &payload)
+		if payload["id"] != "6-1" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddIssueTag(context.Background(), "PROJ-1", "6-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_RemoveIssueTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/tags/6-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.RemoveIssueTag(context.Background(), "PROJ-1", "6-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -8,13 +9,13 @@ import (
 	"github.com/cf/lazytrack/internal/model"
 )
 
-func (c *Client) SearchUsers(query string) ([]model.User, error) {
+func (c *Client) SearchUsers(ctx context.Context, query string) ([]model.User, error) {
 	params := url.Values{}
 	params.Set("fields", "id,login,fullName")
 	params.Set("query", query)
 	params.Set("$top", "10")
 
-	resp, err := c.get("/api/users", params)
+	resp, err := c.get(ctx, "/api/users", params)
 	if err != nil {
 		return nil, fmt.Errorf("searching users: %w", err)
 	}
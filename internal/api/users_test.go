@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,7 +25,7 @@ func TestClient_SearchUsers(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	users, err := client.SearchUsers("john")
+	users, err := client.SearchUsers(context.Background(), "john")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -47,7 +48,7 @@ func TestClient_SearchUsers_Empty(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-token")
-	users, err := client.SearchUsers("nonexistent")
+	users, err := client.SearchUsers(context.Background(), "nonexistent")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+const workItemFields = "id,author(login,fullName),date,duration(minutes,presentation),text,type(id,name)"
+
+func (c *Client) ListWorkItems(ctx context.Context, issueID string) ([]model.WorkItem, error) {
+	params := url.Values{}
+	params.Set("fields", workItemFields)
+
+	resp, err := c.get(ctx, workItemsPath(issueID), params)
+	if err != nil {
+		return nil, fmt.Errorf("listing work items for %s: %w", issueID, err)
+	}
+	defer resp.Body.Close()
+
+	var items []model.WorkItem
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("decoding work items: %w", err)
+	}
+
+	return items, nil
+}
+
+// CreateWorkItem logs time on an issue. Only Date, Duration.Minutes, Text
+// and Type (by ID) of item are sent; a zero Date means today.
+func (c *Client) CreateWorkItem(ctx context.Context, issueID string, item model.WorkItem) (*model.WorkItem, error) {
+	return c.saveWorkItem(ctx, workItemsPath(issueID), item, "logging work on "+issueID)
+}
+
+// UpdateWorkItem replaces the date, duration, text and type of a work item.
+func (c *Client) UpdateWorkItem(ctx context.Context, issueID, itemID string, item model.WorkItem) (*model.WorkItem, error) {
+	return c.saveWorkItem(ctx, workItemsPath(issueID)+"/"+url.PathEscape(itemID), item, "updating work item "+itemID)
+}
+
+func (c *Client) DeleteWorkItem(ctx context.Context, issueID, itemID string) error {
+	if err := c.doDelete(ctx, workItemsPath(issueID)+"/"+url.PathEscape(itemID)); err != nil {
+		return fmt.Errorf("deleting work item %s: %w", itemID, err)
+	}
+	return nil
+}
+
+// ListWorkItemTypes returns the work types enabled for a project.
+func (c *Client) ListWorkItemTypes(ctx context.Context, projectID string) ([]model.WorkItemType, error) {
+	params := url.Values{}
+	params.Set("fields", "id,name")
+
+	resp, err := c.get(ctx, "/api/admin/projects/"+url.PathEscape(projectID)+"/timeTrackingSettings/workItemTypes", params)
+	if err != nil {
+		return nil, fmt.Errorf("listing work types for project %s: %w", projectID, err)
+	}
+	defer resp.Body.Close()
+
+	var types []model.WorkItemType
+	if err := json.NewDecoder(resp.Body).Decode(&types); err != nil {
+		return nil, fmt.Errorf("decoding work types: %w", err)
+	}
+
+	return types, nil
+}
+
+func (c *Client) saveWorkItem(ctx context.Context, path string, item model.WorkItem, action string) (*model.WorkItem, error) {
+	payload := map[string]any{
+		"duration": map[string]int{"minutes": item.Duration.Minutes},
+		"text":     item.Text,
+	}
+	if item.Date != 0 {
+		payload["date"] = item.Date
+	}
+	if item.Type != nil && item.Type.ID != "" {
+		payload["type"] = map[string]string{"id": item.Type.ID}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling work item: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("fields", workItemFields)
+
+	resp, err := c.post(ctx, path+"?"+params.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	var saved model.WorkItem
+	if err := json.NewDecoder(resp.Body).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("decoding work item: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func workItemsPath(issueID string) string {
+	return "/api/issues/" + url.PathEscape(issueID) + "/timeTracking/workItems"
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func TestClient_ListWorkItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/issues/PROJ-1/timeTracking/workItems" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"99-1","author":{"login":"john"},"date":1700000000000,"duration":{"minutes":90,"presentation":"1h 30m"},"text":"Investigation","type":{"id":"71-0","name":"Development"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	items, err := client.ListWorkItems(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d items, want 1", len(items))
+	}
+	if items[0].Duration.Minutes != 90 || items[0].Type.Name != "Development" {
+		t.Errorf("unexpected item: %+v", items[0])
+	}
+}
+
+func TestClient_CreateWorkItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		if r.URL.Path != "/api/issues/PROJ-1/timeTracking/workItems" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload struct {
+			Duration struct{ Minutes int } `json:"duration"`
+			Text     string                `json:"text"`
+			Date     *int64                `json:"date"`
+			Type     struct{ ID string }   `json:"type"`
+		}
+		json.Unmarshal(body, &payload)
+		if payload.Duration.Minutes != 45 || payload.Text != "Review" || payload.Type.ID != "71-1" {
+			t.Errorf("unexpected payload: %s", body)
+		}
+		if payload.Date != nil {
+			t.Errorf("zero date should be omitted, got %d", *payload.Date)
+		}
+		w.Write([]byte(`{"id":"99-2","duration":{"minutes":45}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	item := model.WorkItem{Duration: model.Period{Minutes: 45}, Text: "Review", Type: &model.WorkItemType{ID: "71-1"}}
+	saved, err := client.CreateWorkItem(context.Background(), "PROJ-1", item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.ID != "99-2" {
+		t.Errorf("got id %q, want 99-2", saved.ID)
+	}
+}
+
+func TestClient_UpdateWorkItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/issues/PROJ-1/timeTracking/workItems/99-1" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"99-1","duration":{"minutes":60}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	saved, err := client.UpdateWorkItem(context.Background(), "PROJ-1", "99-1", model.WorkItem{Duration: model.Period{Minutes: 60}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.Duration.Minutes != 60 {
+		t.Errorf("got %d minutes, want 60", saved.Duration.Minutes)
+	}
+}
+
+func TestClient_DeleteWorkItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/issues/PROJ-1/timeTracking/workItems/99-1" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.DeleteWorkItem(context.Background(), "PROJ-1", "99-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_ListWorkItemTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/admin/projects/0-1/timeTrackingSettings/workItemTypes" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"71-0","name":"Development"},{"id":"71-1","name":"Testing"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	types, err := client.ListWorkItemTypes(context.Background(), "0-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != 2 || types[1].Name != "Testing" {
+		t.Errorf("unexpected types: %+v", types)
+	}
+}
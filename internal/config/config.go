@@ -6,18 +6,42 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/adrg/xdg"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server ServerConfig `yaml:"server"`
+	Server   ServerConfig   `yaml:"server"`
+	Searches SearchesConfig `yaml:"searches,omitempty"`
 }
 
 type ServerConfig struct {
-	URL   string `yaml:"url"`
-	Token string `yaml:"token"`
+	URL   string      `yaml:"url"`
+	Token string      `yaml:"token"`
+	Retry RetryConfig `yaml:"retry,omitempty"`
+}
+
+// RetryConfig tunes retries of failed API requests. Zero values fall back to
+// the client defaults (3 attempts, 500ms base delay, 10s max delay, GET only).
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts,omitempty"`
+	BaseDelay   time.Duration `yaml:"base_delay,omitempty"`
+	MaxDelay    time.Duration `yaml:"max_delay,omitempty"`
+	RetryWrites bool          `yaml:"retry_writes,omitempty"` // also retry POST/DELETE; POST only on 429/503
+}
+
+// SearchesConfig holds named searches shown in the saved searches list.
+type SearchesConfig struct {
+	Sync  bool          `yaml:"sync,omitempty"` // also list and save YouTrack saved queries
+	Saved []SavedSearch `yaml:"saved,omitempty"`
+}
+
+// SavedSearch is a YouTrack query saved under a name.
+type SavedSearch struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
 }
 
 // Validate checks that required fields are present and valid.
@@ -31,6 +55,17 @@ func (c *Config) Validate() error {
 	if c.Server.Token == "" {
 		return fmt.Errorf("server.token is required")
 	}
+	if c.Server.Retry.MaxAttempts < 0 {
+		return fmt.Errorf("server.retry.max_attempts must not be negative")
+	}
+	if c.Server.Retry.BaseDelay < 0 || c.Server.Retry.MaxDelay < 0 {
+		return fmt.Errorf("server.retry delays must not be negative")
+	}
+	for i, s := range c.Searches.Saved {
+		if s.Name == "" || s.Query == "" {
+			return fmt.Errorf("searches.saved[%d] needs a name and a query", i)
+		}
+	}
 	return nil
 }
 
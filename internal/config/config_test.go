@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -109,3 +110,91 @@ func TestDefaultPath(t *testing.T) {
 		t.Fatal("expected non-empty default path")
 	}
 }
+
+func TestLoadConfig_Retry(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	content := []byte(`server:
+  url: "https://youtrack.example.com"
+  token: "perm:test-token"
+  retry:
+    max_attempts: 5
+    base_delay: 250ms
+    max_delay: 30s
+    retry_writes: true
+`)
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := cfg.Server.Retry
+	if r.MaxAttempts != 5 {
+		t.Errorf("got MaxAttempts %d, want 5", r.MaxAttempts)
+	}
+	if r.BaseDelay != 250*time.Millisecond {
+		t.Errorf("got BaseDelay %v, want 250ms", r.BaseDelay)
+	}
+	if r.MaxDelay != 30*time.Second {
+		t.Errorf("got MaxDelay %v, want 30s", r.MaxDelay)
+	}
+	if !r.RetryWrites {
+		t.Error("got RetryWrites false, want true")
+	}
+}
+
+func TestValidate_NegativeRetryAttempts(t *testing.T) {
+	cfg := &Config{Server: ServerConfig{
+		URL:   "https://example.com",
+		Token: "perm:test",
+		Retry: RetryConfig{MaxAttempts: -1},
+	}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for negative max_attempts")
+	}
+}
+
+func TestLoadConfig_Searches(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	content := []byte(`server:
+  url: "https://youtrack.example.com"
+  token: "perm:test-token"
+searches:
+  sync: true
+  saved:
+    - name: My open
+      query: "for: me #Unresolved"
+`)
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Searches.Sync {
+		t.Error("got Sync false, want true")
+	}
+	want := SavedSearch{Name: "My open", Query: "for: me #Unresolved"}
+	if len(cfg.Searches.Saved) != 1 || cfg.Searches.Saved[0] != want {
+		t.Errorf("got searches %+v, want [%+v]", cfg.Searches.Saved, want)
+	}
+}
+
+func TestValidate_UnnamedSearch(t *testing.T) {
+	cfg := &Config{
+		Server:   ServerConfig{URL: "https://example.com", Token: "perm:test"},
+		Searches: SearchesConfig{Saved: []SavedSearch{{Query: "#Unresolved"}}},
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for a search without a name")
+	}
+}
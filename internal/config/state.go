@@ -4,13 +4,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/adrg/xdg"
 	"gopkg.in/yaml.v3"
 )
 
 type State struct {
-	UI UIState `yaml:"ui"`
+	UI           UIState     `yaml:"ui"`
+	Timer        *TimerState `yaml:"timer,omitempty"`
+	QueryHistory []string    `yaml:"query_history,omitempty"` // executed search queries, newest first
+}
+
+// TimerState is a running work timer. It survives restarts so time keeps
+// counting while lazytrack is closed.
+type TimerState struct {
+	IssueID string    `yaml:"issue_id"`
+	Started time.Time `yaml:"started"`
 }
 
 type UIState struct {
@@ -19,6 +29,9 @@ type UIState struct {
 	SelectedIssue string  `yaml:"selected_issue"`
 	ActiveProject       string  `yaml:"active_project,omitempty"`
 	LastCheckedMentions int64   `yaml:"last_checked_mentions,omitempty"`
+	TreeMode            bool    `yaml:"tree_mode,omitempty"`
+	HideEmptyFields     bool    `yaml:"hide_empty_fields,omitempty"`
+	SearchesViewed      map[string]int64 `yaml:"searches_viewed,omitempty"` // saved search name → last opened, Unix ms
 }
 
 // DefaultState returns a State with sensible defaults.
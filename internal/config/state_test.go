@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadState_Valid(t *testing.T) {
@@ -196,6 +197,46 @@ func TestLoadState_LastCheckedMentions_DefaultsToZero(t *testing.T) {
 	}
 }
 
+func TestSaveState_Timer_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.yaml")
+
+	started := time.Date(2024, 2, 7, 9, 30, 0, 0, time.UTC)
+	state := DefaultState()
+	state.Timer = &TimerState{IssueID: "PROJ-7", Started: started}
+
+	if err := SaveStateToPath(path, state); err != nil {
+		t.Fatalf("save error: %v", err)
+	}
+
+	loaded := LoadStateFromPath(path)
+
+	if loaded.Timer == nil {
+		t.Fatal("expected timer to be restored")
+	}
+	if loaded.Timer.IssueID != "PROJ-7" || !loaded.Timer.Started.Equal(started) {
+		t.Errorf("got timer %+v, want PROJ-7 started %v", *loaded.Timer, started)
+	}
+}
+
+func TestSaveState_QueryHistory_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.yaml")
+
+	state := DefaultState()
+	state.QueryHistory = []string{"#Unresolved", "for: me"}
+
+	if err := SaveStateToPath(path, state); err != nil {
+		t.Fatalf("save error: %v", err)
+	}
+
+	loaded := LoadStateFromPath(path)
+
+	if len(loaded.QueryHistory) != 2 || loaded.QueryHistory[0] != "#Unresolved" || loaded.QueryHistory[1] != "for: me" {
+		t.Errorf("got QueryHistory %q, want [#Unresolved for: me]", loaded.QueryHistory)
+	}
+}
+
 func TestSaveState_CreatesDirectory(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "nested", "dir", "state.yaml")
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Activity categories requested from YouTrack's activities API.
+const (
+	ActivityIssueCreated = "IssueCreatedCategory"
+	ActivityCustomField  = "CustomFieldCategory"
+	ActivitySummary      = "SummaryCategory"
+	ActivityDescription  = "DescriptionCategory"
+	ActivityComments     = "CommentsCategory"
+	ActivityLinks        = "LinksCategory"
+	ActivityTags         = "TagsCategory"
+	ActivityAttachments  = "AttachmentsCategory"
+	ActivityResolved     = "IssueResolvedCategory"
+)
+
+// ActivityCategories lists every category shown in the history feed.
+var ActivityCategories = []string{
+	ActivityIssueCreated,
+	ActivityCustomField,
+	ActivitySummary,
+	ActivityDescription,
+	ActivityComments,
+	ActivityLinks,
+	ActivityTags,
+	ActivityAttachments,
+	ActivityResolved,
+}
+
+// Activity is one change in an issue's history. Added and Removed hold
+// whatever the change involved: entities such as users, enum values,
+// comments or issues, or plain strings and numbers for simple fields.
+type Activity struct {
+	ID        string           `json:"id"`
+	Timestamp int64            `json:"timestamp"`
+	Author    *User            `json:"author"`
+	Category  ActivityCategory `json:"category"`
+	Field     *ActivityField   `json:"field"`
+	Added     json.RawMessage  `json:"added"`
+	Removed   json.RawMessage  `json:"removed"`
+}
+
+type ActivityCategory struct {
+	ID string `json:"id"`
+}
+
+type ActivityField struct {
+	Name string `json:"name"`
+}
+
+// maxActivityValueLen caps long values such as comment or description text.
+const maxActivityValueLen = 80
+
+// Describe summarizes the change, e.g. "State: Open → In Progress" or
+// "commented: Looks good".
+func (a *Activity) Describe() string {
+	added := activityValue(a.Added)
+	removed := activityValue(a.Removed)
+
+	switch a.Category.ID {
+	case ActivityIssueCreated:
+		return "created the issue"
+	case ActivityComments:
+		switch {
+		case added != "" && removed != "":
+			return "edited a comment: " + added
+		case added != "":
+			return "commented: " + added
+		default:
+			return "deleted a comment"
+		}
+	case ActivityDescription:
+		return "edited the description"
+	case ActivityResolved:
+		if added != "" {
+			return "resolved the issue"
+		}
+		return "reopened the issue"
+	}
+
+	field := "changed"
+	if a.Field != nil && a.Field.Name != "" {
+		field = a.Field.Name
+	}
+	switch {
+	case added != "" && removed != "":
+		return fmt.Sprintf("%s: %s → %s", field, removed, added)
+	case added != "":
+		return fmt.Sprintf("%s: +%s", field, added)
+	case removed != "":
+		return fmt.Sprintf("%s: −%s", field, removed)
+	default:
+		return field
+	}
+}
+
+// activityValue renders an added/removed value for display. Entity lists
+// are joined by ", "; null and empty lists give "".
+func activityValue(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return ""
+	}
+
+	var s string
+	switch v := v.(type) {
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			if name := activityEntityName(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		s = strings.Join(names, ", ")
+	default:
+		s = activityEntityName(v)
+	}
+
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxActivityValueLen {
+		s = string(r[:maxActivityValueLen-1]) + "…"
+	}
+	return s
+}
+
+// activityEntityName picks the most readable property of an entity.
+func activityEntityName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case map[string]any:
+		for _, key := range []string{"idReadable", "fullName", "presentation", "name", "login", "text"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestActivity_Describe(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity Activity
+		want     string
+	}{
+		{
+			"field change",
+			Activity{
+				Category: ActivityCategory{ID: ActivityCustomField},
+				Field:    &ActivityField{Name: "State"},
+				Removed:  json.RawMessage(`[{"name":"Open"}]`),
+				Added:    json.RawMessage(`[{"name":"In Progress"}]`),
+			},
+			"State: Open → In Progress",
+		},
+		{
+			"assignee set",
+			Activity{
+				Category: ActivityCategory{ID: ActivityCustomField},
+				Field:    &ActivityField{Name: "Assignee"},
+				Removed:  json.RawMessage(`[]`),
+				Added:    json.RawMessage(`[{"login":"jane","fullName":"Jane Doe"}]`),
+			},
+			"Assignee: +Jane Doe",
+		},
+		{
+			"summary",
+			Activity{
+				Category: ActivityCategory{ID: ActivitySummary},
+				Field:    &ActivityField{Name: "summary"},
+				Removed:  json.RawMessage(`"Old title"`),
+				Added:    json.RawMessage(`"New title"`),
+			},
+			"summary: Old title → New title",
+		},
+		{
+			"link removed",
+			Activity{
+				Category: ActivityCategory{ID: ActivityLinks},
+				Field:    &ActivityField{Name: "depends on"},
+				Removed:  json.RawMessage(`[{"idReadable":"PROJ-9","summary":"Schema"}]`),
+			},
+			"depends on: −PROJ-9",
+		},
+		{
+			"comment",
+			Activity{
+				Category: ActivityCategory{ID: ActivityComments},
+				Added:    json.RawMessage(`[{"text":"Looks\ngood"}]`),
+			},
+			"commented: Looks good",
+		},
+		{
+			"comment deleted",
+			Activity{
+				Category: ActivityCategory{ID: ActivityComments},
+				Removed:  json.RawMessage(`[{"text":"oops"}]`),
+			},
+			"deleted a comment",
+		},
+		{
+			"created",
+			Activity{Category: ActivityCategory{ID: ActivityIssueCreated}},
+			"created the issue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.activity.Describe(); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActivityValue_Truncates(t *testing.T) {
+	long := `"` + strings.Repeat("a", 100) + `"`
+	got := []rune(activityValue(json.RawMessage(long)))
+	if len(got) != maxActivityValueLen || got[len(got)-1] != '…' {
+		t.Errorf("got %d runes ending %q, want %d ending …", len(got), got[len(got)-1], maxActivityValueLen)
+	}
+}
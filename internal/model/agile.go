@@ -0,0 +1,102 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Agile is a YouTrack agile board.
+type Agile struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Projects       []Project      `json:"projects"`
+	ColumnSettings ColumnSettings `json:"columnSettings"`
+	CurrentSprint  *Sprint        `json:"currentSprint"`
+}
+
+// ColumnSettings says which field a board's columns are based on.
+type ColumnSettings struct {
+	Field   *CustomFieldRef `json:"field"`
+	Columns []AgileColumn   `json:"columns"`
+}
+
+// CustomFieldRef identifies a custom field by name.
+type CustomFieldRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AgileColumn is a board column. It holds the issues whose column field has
+// one of its values.
+type AgileColumn struct {
+	ID           string        `json:"id"`
+	Presentation string        `json:"presentation"`
+	FieldValues  []BundleValue `json:"fieldValues"`
+}
+
+// Sprint is an iteration of an agile board. Issues is only filled when
+// requested.
+type Sprint struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Start    int64   `json:"start"`
+	Finish   int64   `json:"finish"`
+	Archived bool    `json:"archived"`
+	Issues   []Issue `json:"issues"`
+}
+
+// ColumnField returns the name of the field the board's columns are based
+// on, "State" unless configured otherwise.
+func (a *Agile) ColumnField() string {
+	if f := a.ColumnSettings.Field; f != nil && f.Name != "" {
+		return f.Name
+	}
+	return "State"
+}
+
+// ColumnOf returns the index of the column holding issue, or -1 if its
+// column field value is on no column.
+func (a *Agile) ColumnOf(issue *Issue) int {
+	value := ""
+	for _, cf := range issue.CustomFields {
+		if cf.Name == a.ColumnField() {
+			value = customFieldValueName(cf.Value)
+			break
+		}
+	}
+	if value == "" {
+		return -1
+	}
+	for i, col := range a.ColumnSettings.Columns {
+		for _, v := range col.FieldValues {
+			if strings.EqualFold(v.Name, value) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Title returns the column's name as shown on the board.
+func (c *AgileColumn) Title() string {
+	if c.Presentation != "" {
+		return c.Presentation
+	}
+	names := make([]string, len(c.FieldValues))
+	for i, v := range c.FieldValues {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// DaysLeft returns the days from now until the sprint finishes, counting
+// a partial day as one; zero or less once it is over. ok is false when the
+// sprint has no finish date.
+func (s *Sprint) DaysLeft(now time.Time) (days int, ok bool) {
+	if s.Finish == 0 {
+		return 0, false
+	}
+	left := time.UnixMilli(s.Finish).Sub(now)
+	return int(math.Ceil(left.Hours() / 24)), true
+}
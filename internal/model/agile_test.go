@@ -0,0 +1,50 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAgile_ColumnOf(t *testing.T) {
+	var a Agile
+	a.ColumnSettings.Columns = []AgileColumn{
+		{Presentation: "To do", FieldValues: []BundleValue{{Name: "Open"}}},
+		{FieldValues: []BundleValue{{Name: "Fixed"}, {Name: "Verified"}}},
+	}
+	issue := func(field, state string) *Issue {
+		return &Issue{CustomFields: []CustomField{{Name: field, Value: json.RawMessage(`{"name":"` + state + `"}`)}}}
+	}
+
+	if got := a.ColumnOf(issue("State", "verified")); got != 1 {
+		t.Errorf("ColumnOf(Verified) = %d, want 1", got)
+	}
+	if got := a.ColumnOf(issue("State", "In Progress")); got != -1 {
+		t.Errorf("expected an unmapped state to be on no column, got %d", got)
+	}
+	a.ColumnSettings.Field = &CustomFieldRef{Name: "Stage"}
+	if got := a.ColumnOf(issue("Stage", "Open")); got != 0 {
+		t.Errorf("expected the configured column field to be used, got %d", got)
+	}
+	if a.ColumnSettings.Columns[0].Title() != "To do" || a.ColumnSettings.Columns[1].Title() != "Fixed, Verified" {
+		t.Error("unexpected column titles")
+	}
+}
+
+func TestSprint_DaysLeft(t *testing.T) {
+	now := time.Date(2025, 7, 10, 9, 0, 0, 0, time.UTC)
+	finish := func(tm time.Time) *Sprint { return &Sprint{Finish: tm.UnixMilli()} }
+
+	if days, ok := finish(now.Add(4*24*time.Hour + time.Hour)).DaysLeft(now); !ok || days != 5 {
+		t.Errorf("expected 5 days left, got %d %v", days, ok)
+	}
+	if days, _ := finish(now.Add(3 * time.Hour)).DaysLeft(now); days != 1 {
+		t.Errorf("expected the last partial day to count, got %d", days)
+	}
+	if days, _ := finish(now.Add(-time.Hour)).DaysLeft(now); days > 0 {
+		t.Errorf("expected a finished sprint to have no days left, got %d", days)
+	}
+	if _, ok := (&Sprint{}).DaysLeft(now); ok {
+		t.Error("expected no days left without a finish date")
+	}
+}
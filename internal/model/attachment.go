@@ -0,0 +1,13 @@
+package model
+
+// Attachment is a file attached to an issue. URL is relative to the
+// YouTrack server root and already carries a signed access token.
+type Attachment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Author   *User  `json:"author"`
+	Created  int64  `json:"created"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	URL      string `json:"url"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "strings"
+
 // BundleValue represents a single value in a YouTrack custom field bundle
 // (e.g., a state like "Open" or a type like "Bug").
 type BundleValue struct {
@@ -12,11 +14,114 @@ type BundleValue struct {
 // including its bundle of allowed values.
 type ProjectCustomField struct {
 	Field struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		Type string `json:"$type"`
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Type      string `json:"$type"`
+		FieldType struct {
+			ID string `json:"id"` // e.g. "enum[1]", "user[*]", "date", "period"
+		} `json:"fieldType"`
 	} `json:"field"`
 	Bundle struct {
 		Values []BundleValue `json:"values"`
 	} `json:"bundle"`
 }
+
+// Kind returns the field's value type without its cardinality, e.g. "enum",
+// "state", "user", "date" or "period". Falls back to the issue field $type
+// when the server did not report a field type.
+func (f *ProjectCustomField) Kind() string {
+	if id := f.Field.FieldType.ID; id != "" {
+		kind, _, _ := strings.Cut(id, "[")
+		return kind
+	}
+	return FieldKind(f.Field.Type)
+}
+
+// Multi reports whether the field holds several values.
+func (f *ProjectCustomField) Multi() bool {
+	if id := f.Field.FieldType.ID; id != "" {
+		return strings.HasSuffix(id, "[*]")
+	}
+	return strings.HasPrefix(f.Field.Type, "Multi")
+}
+
+// IssueFieldType returns the $type of this field on an issue, e.g.
+// "SingleEnumIssueCustomField", as needed when setting its value.
+func (f *ProjectCustomField) IssueFieldType() string {
+	if strings.HasSuffix(f.Field.Type, "IssueCustomField") {
+		return f.Field.Type
+	}
+	cardinality := "Single"
+	if f.Multi() {
+		cardinality = "Multi"
+	}
+	switch f.Kind() {
+	case "state":
+		return "StateIssueCustomField"
+	case "enum":
+		return cardinality + "EnumIssueCustomField"
+	case "user":
+		return cardinality + "UserIssueCustomField"
+	case "version":
+		return cardinality + "VersionIssueCustomField"
+	case "build":
+		return cardinality + "BuildIssueCustomField"
+	case "ownedField":
+		return cardinality + "OwnedIssueCustomField"
+	case "date":
+		return "DateIssueCustomField"
+	case "period":
+		return "PeriodIssueCustomField"
+	case "text":
+		return "TextIssueCustomField"
+	default:
+		return "SimpleIssueCustomField"
+	}
+}
+
+// FieldKind maps an issue custom field $type to its value kind as returned
+// by Kind. Returns "" for simple fields, whose kind the $type doesn't tell.
+func FieldKind(issueFieldType string) string {
+	t := strings.TrimPrefix(strings.TrimPrefix(issueFieldType, "Single"), "Multi")
+	switch {
+	case strings.HasPrefix(t, "State"):
+		return "state"
+	case strings.HasPrefix(t, "Enum"):
+		return "enum"
+	case strings.HasPrefix(t, "User"):
+		return "user"
+	case strings.HasPrefix(t, "Version"):
+		return "version"
+	case strings.HasPrefix(t, "Build"):
+		return "build"
+	case strings.HasPrefix(t, "Owned"):
+		return "ownedField"
+	case t == "DateIssueCustomField":
+		return "date"
+	case t == "PeriodIssueCustomField":
+		return "period"
+	case t == "TextIssueCustomField":
+		return "text"
+	default:
+		return ""
+	}
+}
+
+// BundleElementType returns the $type of a bundle value for a value kind,
+// e.g. "EnumBundleElement" for "enum", or "" if the kind has no bundle.
+func BundleElementType(kind string) string {
+	switch kind {
+	case "state":
+		return "StateBundleElement"
+	case "enum":
+		return "EnumBundleElement"
+	case "version":
+		return "VersionBundleElement"
+	case "build":
+		return "BuildBundleElement"
+	case "ownedField":
+		return "OwnedBundleElement"
+	default:
+		return ""
+	}
+}
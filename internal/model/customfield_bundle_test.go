@@ -0,0 +1,35 @@
+package model
+
+import "testing"
+
+func TestProjectCustomField_Kinds(t *testing.T) {
+	tests := []struct {
+		fieldType string // issue field $type reported for the field
+		typeID    string // fieldType.id
+		kind      string
+		multi     bool
+		issueType string
+	}{
+		{"CustomField", "enum[1]", "enum", false, "SingleEnumIssueCustomField"},
+		{"CustomField", "version[*]", "version", true, "MultiVersionIssueCustomField"},
+		{"CustomField", "date", "date", false, "DateIssueCustomField"},
+		{"CustomField", "integer", "integer", false, "SimpleIssueCustomField"},
+		{"StateMachineIssueCustomField", "", "state", false, "StateMachineIssueCustomField"},
+		{"MultiUserIssueCustomField", "", "user", true, "MultiUserIssueCustomField"},
+		{"PeriodIssueCustomField", "", "period", false, "PeriodIssueCustomField"},
+	}
+	for _, tt := range tests {
+		var f ProjectCustomField
+		f.Field.Type = tt.fieldType
+		f.Field.FieldType.ID = tt.typeID
+		if got := f.Kind(); got != tt.kind {
+			t.Errorf("%s/%s: Kind() = %q, want %q", tt.fieldType, tt.typeID, got, tt.kind)
+		}
+		if got := f.Multi(); got != tt.multi {
+			t.Errorf("%s/%s: Multi() = %v, want %v", tt.fieldType, tt.typeID, got, tt.multi)
+		}
+		if got := f.IssueFieldType(); got != tt.issueType {
+			t.Errorf("%s/%s: IssueFieldType() = %q, want %q", tt.fieldType, tt.typeID, got, tt.issueType)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is how date field values are written, e.g. "2025-07-01".
+const DateLayout = "2006-01-02"
+
+// ErrUnsupportedField is returned by ParseFieldValue for kinds of fields it
+// can't parse.
+var ErrUnsupportedField = errors.New("unsupported kind of field")
+
+// FieldValue is the typed value of an issue custom field. It is one of
+// BundleField, UserField, DateField, PeriodField, IntegerField, FloatField,
+// StringField or TextField.
+type FieldValue interface {
+	// Empty reports whether the field has no value.
+	Empty() bool
+	// Display formats the value for reading, e.g. "Major" or "1h 30m".
+	Display() string
+	// Strings returns the value as text to edit: bundle value names, user
+	// logins, dates as YYYY-MM-DD and periods like "1h 30m". Parsing them
+	// back with ParseFieldValue gives the same value.
+	Strings() []string
+	// Payload returns the JSON value that sets the field in an issue update,
+	// nil to clear it.
+	Payload() any
+
+	fieldValue()
+}
+
+// BundleField holds the values of enum, state, version, build and owned
+// fields, which are picked from a bundle.
+type BundleField struct {
+	Kind   string // "enum", "state", "version", "build" or "ownedField"
+	Multi  bool
+	Values []BundleValue
+}
+
+// UserField holds the users of a user or multi-user field.
+type UserField struct {
+	Multi bool
+	Users []User
+}
+
+// DateField holds a date as Unix milliseconds; nil when unset.
+type DateField struct {
+	Millis *int64
+}
+
+// PeriodField holds a duration in minutes, such as an estimation; nil when
+// unset.
+type PeriodField struct {
+	Minutes *int
+}
+
+// IntegerField holds a whole number; nil when unset.
+type IntegerField struct {
+	Value *int
+}
+
+// FloatField holds a number; nil when unset.
+type FloatField struct {
+	Value *float64
+}
+
+// StringField holds a single-line string.
+type StringField struct {
+	Text string
+}
+
+// TextField holds multi-line text.
+type TextField struct {
+	Text string
+}
+
+func (BundleField) fieldValue()  {}
+func (UserField) fieldValue()    {}
+func (DateField) fieldValue()    {}
+func (PeriodField) fieldValue()  {}
+func (IntegerField) fieldValue() {}
+func (FloatField) fieldValue()   {}
+func (StringField) fieldValue()  {}
+func (TextField) fieldValue()    {}
+
+func (f BundleField) Empty() bool  { return len(f.Values) == 0 }
+func (f UserField) Empty() bool    { return len(f.Users) == 0 }
+func (f DateField) Empty() bool    { return f.Millis == nil }
+func (f PeriodField) Empty() bool  { return f.Minutes == nil }
+func (f IntegerField) Empty() bool { return f.Value == nil }
+func (f FloatField) Empty() bool   { return f.Value == nil }
+func (f StringField) Empty() bool  { return f.Text == "" }
+func (f TextField) Empty() bool    { return f.Text == "" }
+
+func (f BundleField) Strings() []string {
+	var names []string
+	for _, v := range f.Values {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func (f BundleField) Display() string { return strings.Join(f.Strings(), ", ") }
+
+func (f BundleField) Payload() any {
+	elems := make([]map[string]string, 0, len(f.Values))
+	for _, v := range f.Values {
+		elemType := v.Type
+		if elemType == "" {
+			elemType = BundleElementType(f.Kind)
+		}
+		elems = append(elems, map[string]string{"name": v.Name, "$type": elemType})
+	}
+	if f.Multi {
+		return elems
+	}
+	if len(elems) == 0 {
+		return nil
+	}
+	return elems[0]
+}
+
+func (f UserField) Strings() []string {
+	var logins []string
+	for _, u := range f.Users {
+		logins = append(logins, u.Login)
+	}
+	return logins
+}
+
+// Display shows full names, falling back to logins.
+func (f UserField) Display() string {
+	names := make([]string, len(f.Users))
+	for i, u := range f.Users {
+		names[i] = u.FullName
+		if names[i] == "" {
+			names[i] = u.Login
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (f UserField) Payload() any {
+	users := make([]map[string]string, 0, len(f.Users))
+	for _, u := range f.Users {
+		users = append(users, map[string]string{"login": u.Login, "$type": "User"})
+	}
+	if f.Multi {
+		return users
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	return users[0]
+}
+
+func (f DateField) Display() string {
+	if f.Millis == nil {
+		return ""
+	}
+	// YouTrack stores dates as UTC midnight; in local time they would show
+	// the previous day west of UTC.
+	return time.UnixMilli(*f.Millis).UTC().Format(DateLayout)
+}
+
+func (f DateField) Strings() []string { return nonEmptyStrings(f.Display()) }
+
+func (f DateField) Payload() any {
+	if f.Millis == nil {
+		return nil
+	}
+	return *f.Millis
+}
+
+func (f PeriodField) Display() string {
+	if f.Minutes == nil {
+		return ""
+	}
+	return FormatMinutes(*f.Minutes)
+}
+
+func (f PeriodField) Strings() []string { return nonEmptyStrings(f.Display()) }
+
+func (f PeriodField) Payload() any {
+	if f.Minutes == nil {
+		return nil
+	}
+	return map[string]any{"minutes": *f.Minutes, "$type": "PeriodValue"}
+}
+
+func (f IntegerField) Display() string {
+	if f.Value == nil {
+		return ""
+	}
+	return strconv.Itoa(*f.Value)
+}
+
+func (f IntegerField) Strings() []string { return nonEmptyStrings(f.Display()) }
+
+func (f IntegerField) Payload() any {
+	if f.Value == nil {
+		return nil
+	}
+	return *f.Value
+}
+
+func (f FloatField) Display() string {
+	if f.Value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f.Value, 'f', -1, 64)
+}
+
+func (f FloatField) Strings() []string { return nonEmptyStrings(f.Display()) }
+
+func (f FloatField) Payload() any {
+	if f.Value == nil {
+		return nil
+	}
+	return *f.Value
+}
+
+func (f StringField) Display() string   { return f.Text }
+func (f StringField) Strings() []string { return nonEmptyStrings(f.Text) }
+
+func (f StringField) Payload() any {
+	if f.Text == "" {
+		return nil
+	}
+	return f.Text
+}
+
+func (f TextField) Display() string   { return f.Text }
+func (f TextField) Strings() []string { return nonEmptyStrings(f.Text) }
+
+func (f TextField) Payload() any {
+	if f.Text == "" {
+		return nil
+	}
+	return map[string]any{"text": f.Text, "$type": "TextFieldValue"}
+}
+
+func nonEmptyStrings(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+// Decode returns the field's typed value.
+func (cf CustomField) Decode() (FieldValue, error) {
+	return DecodeFieldValue(cf.Type, cf.Value)
+}
+
+// DecodeFieldValue decodes a custom field's JSON value according to its
+// issue field $type. Simple fields, whose $type doesn't tell integers,
+// floats and strings apart, are decoded by the shape of the value.
+func DecodeFieldValue(fieldType string, raw json.RawMessage) (FieldValue, error) {
+	if len(raw) == 0 {
+		raw = json.RawMessage("null")
+	}
+	kind := FieldKind(fieldType)
+	multi := strings.HasPrefix(fieldType, "Multi")
+
+	if BundleElementType(kind) != "" {
+		values, err := decodeOneOrMany[BundleValue](raw, multi)
+		return BundleField{Kind: kind, Multi: multi, Values: values}, err
+	}
+	switch kind {
+	case "user":
+		users, err := decodeOneOrMany[User](raw, multi)
+		return UserField{Multi: multi, Users: users}, err
+	case "date":
+		var f DateField
+		err := json.Unmarshal(raw, &f.Millis)
+		return f, err
+	case "period":
+		var p *Period
+		if err := json.Unmarshal(raw, &p); err != nil || p == nil {
+			return PeriodField{}, err
+		}
+		return PeriodField{Minutes: &p.Minutes}, nil
+	case "text":
+		var t *struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(raw, &t); err != nil || t == nil {
+			return TextField{}, err
+		}
+		return TextField{Text: t.Text}, nil
+	}
+
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return StringField{}, nil
+	case string:
+		return StringField{Text: v}, nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			n := int(v)
+			return IntegerField{Value: &n}, nil
+		}
+		return FloatField{Value: &v}, nil
+	}
+	return nil, fmt.Errorf("unexpected value for %s: %s", fieldType, raw)
+}
+
+// decodeOneOrMany decodes a list, or a single object or null when the
+// field holds one value.
+func decodeOneOrMany[T any](raw json.RawMessage, multi bool) ([]T, error) {
+	if multi {
+		var items []T
+		err := json.Unmarshal(raw, &items)
+		return items, err
+	}
+	var item *T
+	if err := json.Unmarshal(raw, &item); err != nil || item == nil {
+		return nil, err
+	}
+	return []T{*item}, nil
+}
+
+// ParseFieldValue parses text items, as returned by Strings, into a value
+// for a field of the given kind (see ProjectCustomField.Kind). No items
+// gives an empty value. Bundle values are taken by name without checking
+// them against the bundle.
+func ParseFieldValue(kind string, multi bool, texts []string) (FieldValue, error) {
+	if len(texts) > 1 && !multi {
+		return nil, fmt.Errorf("takes a single value")
+	}
+
+	if BundleElementType(kind) != "" {
+		f := BundleField{Kind: kind, Multi: multi}
+		for _, t := range texts {
+			f.Values = append(f.Values, BundleValue{Name: t})
+		}
+		return f, nil
+	}
+	if kind == "user" {
+		f := UserField{Multi: multi}
+		for _, t := range texts {
+			f.Users = append(f.Users, User{Login: strings.TrimPrefix(t, "@")})
+		}
+		return f, nil
+	}
+
+	text := ""
+	if len(texts) > 0 {
+		text = texts[0]
+	}
+	switch kind {
+	case "date":
+		if text == "" {
+			return DateField{}, nil
+		}
+		t, err := time.ParseInLocation(DateLayout, text, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("date must be %s", DateLayout)
+		}
+		ms := t.UnixMilli()
+		return DateField{Millis: &ms}, nil
+	case "period":
+		if text == "" {
+			return PeriodField{}, nil
+		}
+		minutes, err := ParseDuration(text)
+		if err != nil {
+			return nil, err
+		}
+		return PeriodField{Minutes: &minutes}, nil
+	case "integer":
+		if text == "" {
+			return IntegerField{}, nil
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", text)
+		}
+		return IntegerField{Value: &n}, nil
+	case "float":
+		if text == "" {
+			return FloatField{}, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		return FloatField{Value: &f}, nil
+	case "string":
+		return StringField{Text: text}, nil
+	case "text":
+		return TextField{Text: text}, nil
+	}
+	return nil, ErrUnsupportedField
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeFieldValue(t *testing.T) {
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	tests := []struct {
+		fieldType string
+		raw       string
+		want      string // FieldValue type
+		display   string
+		strings   []string
+	}{
+		{"SingleEnumIssueCustomField", `{"name":"Major","$type":"EnumBundleElement"}`, "BundleField", "Major", []string{"Major"}},
+		{"MultiEnumIssueCustomField", `[{"name":"UI"},{"name":"Backend"}]`, "BundleField", "UI, Backend", []string{"UI", "Backend"}},
+		{"StateMachineIssueCustomField", `{"name":"Open"}`, "BundleField", "Open", []string{"Open"}},
+		{"MultiVersionIssueCustomField", `[]`, "BundleField", "", nil},
+		{"SingleBuildIssueCustomField", `null`, "BundleField", "", nil},
+		{"SingleOwnedIssueCustomField", `{"name":"Backend"}`, "BundleField", "Backend", []string{"Backend"}},
+		{"SingleUserIssueCustomField", `{"login":"jane","fullName":"Jane Doe"}`, "UserField", "Jane Doe", []string{"jane"}},
+		{"MultiUserIssueCustomField", `[{"login":"jane"},{"login":"bob","fullName":"Bob"}]`, "UserField", "jane, Bob", []string{"jane", "bob"}},
+		{"DateIssueCustomField", string(mustJSON(t, due)), "DateField", "2025-07-01", []string{"2025-07-01"}},
+		{"DateIssueCustomField", `null`, "DateField", "", nil},
+		{"PeriodIssueCustomField", `{"minutes":90,"presentation":"1h 30m"}`, "PeriodField", "1h 30m", []string{"1h 30m"}},
+		{"TextIssueCustomField", `{"text":"line 1\nline 2"}`, "TextField", "line 1\nline 2", []string{"line 1\nline 2"}},
+		{"SimpleIssueCustomField", `3`, "IntegerField", "3", []string{"3"}},
+		{"SimpleIssueCustomField", `2.5`, "FloatField", "2.5", []string{"2.5"}},
+		{"SimpleIssueCustomField", `"abc"`, "StringField", "abc", []string{"abc"}},
+		{"SimpleIssueCustomField", `null`, "StringField", "", nil},
+	}
+	for _, tt := range tests {
+		v, err := DecodeFieldValue(tt.fieldType, json.RawMessage(tt.raw))
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.fieldType, tt.raw, err)
+			continue
+		}
+		if got := reflect.TypeOf(v).Name(); got != tt.want {
+			t.Errorf("%s %s: decoded as %s, want %s", tt.fieldType, tt.raw, got, tt.want)
+		}
+		if got := v.Display(); got != tt.display {
+			t.Errorf("%s %s: Display() = %q, want %q", tt.fieldType, tt.raw, got, tt.display)
+		}
+		if got := v.Strings(); !reflect.DeepEqual(got, tt.strings) {
+			t.Errorf("%s %s: Strings() = %q, want %q", tt.fieldType, tt.raw, got, tt.strings)
+		}
+		if v.Empty() != (tt.strings == nil) {
+			t.Errorf("%s %s: Empty() = %v", tt.fieldType, tt.raw, v.Empty())
+		}
+	}
+}
+
+func TestDecodeFieldValue_Malformed(t *testing.T) {
+	if _, err := DecodeFieldValue("SingleUserIssueCustomField", json.RawMessage(`"jane"`)); err == nil {
+		t.Error("expected a string to be rejected as a user")
+	}
+	if _, err := DecodeFieldValue("SimpleIssueCustomField", json.RawMessage(`{"a":1}`)); err == nil {
+		t.Error("expected an object to be rejected as a simple value")
+	}
+}
+
+func TestFieldValue_Payload(t *testing.T) {
+	minutes, n := 90, 5
+	text := "notes"
+	tests := []struct {
+		name  string
+		value FieldValue
+		want  string
+	}{
+		{"enum", BundleField{Kind: "enum", Values: []BundleValue{{Name: "Major"}}}, `{"$type":"EnumBundleElement","name":"Major"}`},
+		{"enum cleared", BundleField{Kind: "enum"}, `null`},
+		{"multi version", BundleField{Kind: "version", Multi: true, Values: []BundleValue{{Name: "1.0"}}}, `[{"$type":"VersionBundleElement","name":"1.0"}]`},
+		{"multi version cleared", BundleField{Kind: "version", Multi: true}, `[]`},
+		{"state keeps element type", BundleField{Kind: "state", Values: []BundleValue{{Name: "Open", Type: "StateBundleElement"}}}, `{"$type":"StateBundleElement","name":"Open"}`},
+		{"user", UserField{Users: []User{{Login: "jane", FullName: "Jane"}}}, `{"$type":"User","login":"jane"}`},
+		{"multi user", UserField{Multi: true, Users: []User{{Login: "jane"}, {Login: "bob"}}}, `[{"$type":"User","login":"jane"},{"$type":"User","login":"bob"}]`},
+		{"period", PeriodField{Minutes: &minutes}, `{"$type":"PeriodValue","minutes":90}`},
+		{"integer", IntegerField{Value: &n}, `5`},
+		{"integer cleared", IntegerField{}, `null`},
+		{"text", TextField{Text: text}, `{"$type":"TextFieldValue","text":"notes"}`},
+		{"string", StringField{Text: text}, `"notes"`},
+	}
+	for _, tt := range tests {
+		if got := string(mustJSON(t, tt.value.Payload())); got != tt.want {
+			t.Errorf("%s: Payload() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseFieldValue_RoundTrip(t *testing.T) {
+	tests := []struct {
+		kind  string
+		multi bool
+		texts []string
+	}{
+		{"enum", false, []string{"Major"}},
+		{"version", true, []string{"1.0", "2.0"}},
+		{"user", true, []string{"jane", "bob"}},
+		{"date", false, []string{"2025-07-01"}},
+		{"period", false, []string{"1h 30m"}},
+		{"integer", false, []string{"-4"}},
+		{"float", false, []string{"0.25"}},
+		{"string", false, []string{"abc"}},
+		{"text", false, []string{"a\nb"}},
+		{"period", false, nil},
+	}
+	for _, tt := range tests {
+		v, err := ParseFieldValue(tt.kind, tt.multi, tt.texts)
+		if err != nil {
+			t.Errorf("%s %q: %v", tt.kind, tt.texts, err)
+			continue
+		}
+		if got := v.Strings(); !reflect.DeepEqual(got, tt.texts) {
+			t.Errorf("%s: Strings() = %q, want %q", tt.kind, got, tt.texts)
+		}
+	}
+}
+
+func TestDateField_UTCWestOfUTC(t *testing.T) {
+	orig := time.Local
+	time.Local = time.FixedZone("PDT", -7*60*60)
+	defer func() { time.Local = orig }()
+
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	if got := (DateField{Millis: &due}).Display(); got != "2025-07-01" {
+		t.Errorf("Display() = %q, want 2025-07-01", got)
+	}
+	v, err := ParseFieldValue("date", false, []string{"2025-07-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Payload(); got != due {
+		t.Errorf("Payload() = %v, want UTC midnight %d", got, due)
+	}
+}
+
+func TestParseFieldValue_Errors(t *testing.T) {
+	tests := []struct {
+		kind  string
+		multi bool
+		texts []string
+	}{
+		{"enum", false, []string{"Major", "Minor"}},
+		{"date", false, []string{"07/01/2025"}},
+		{"period", false, []string{"soon"}},
+		{"integer", false, []string{"1.5"}},
+		{"float", false, []string{"lots"}},
+	}
+	for _, tt := range tests {
+		if _, err := ParseFieldValue(tt.kind, tt.multi, tt.texts); err == nil {
+			t.Errorf("%s %q: expected an error", tt.kind, tt.texts)
+		}
+	}
+	if _, err := ParseFieldValue("", false, []string{"x"}); !errors.Is(err, ErrUnsupportedField) {
+		t.Errorf("expected ErrUnsupportedField for an unknown kind, got %v", err)
+	}
+}
+
+func TestParseFieldValue_UserStripsAt(t *testing.T) {
+	v, err := ParseFieldValue("user", false, []string{"@jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Strings(); len(got) != 1 || got[0] != "jane" {
+		t.Errorf("expected login without @, got %q", got)
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -13,6 +13,10 @@ type Issue struct {
 	Reporter     *User         `json:"reporter"`
 	Project      *Project      `json:"project"`
 	Comments     []Comment     `json:"comments"`
+	Attachments  []Attachment  `json:"attachments"`
+	Links        []IssueLink   `json:"links"`
+	Parent       *IssueLink    `json:"parent"`
+	Tags         []Tag         `json:"tags"`
 	CustomFields []CustomField `json:"customFields"`
 }
 
@@ -62,6 +66,15 @@ func customFieldValueUser(raw json.RawMessage) *User {
 	return u
 }
 
+// ParentID returns the readable ID of the issue this one is a subtask of,
+// or "" if it has no parent.
+func (i *Issue) ParentID() string {
+	if i.Parent == nil || len(i.Parent.Issues) == 0 {
+		return ""
+	}
+	return i.Parent.Issues[0].IDReadable
+}
+
 // TypeValue extracts the "Type" custom field value name, returns "" if not found.
 func (i *Issue) TypeValue() string {
 	for _, cf := range i.CustomFields {
@@ -92,6 +105,17 @@ func (i *Issue) AssigneeValue() *User {
 	return nil
 }
 
+// PeriodValue extracts the minutes of a period custom field such as
+// "Estimation" or "Spent time". Returns false if the field is missing or empty.
+func (i *Issue) PeriodValue(name string) (int, bool) {
+	for _, cf := range i.CustomFields {
+		if cf.Name == name {
+			return customFieldValuePeriod(cf.Value)
+		}
+	}
+	return 0, false
+}
+
 // StateFieldType returns the $type of the "State" custom field (e.g. "StateIssueCustomField"
 // or "StateMachineIssueCustomField"). Returns "" if not found.
 func (i *Issue) StateFieldType() string {
@@ -215,3 +215,27 @@ func TestIssue_TypeFieldType(t *testing.T) {
 		})
 	}
 }
+
+func TestIssue_ParentID(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{"subtask", `{"parent":{"issues":[{"idReadable":"PROJ-1"}]}}`, "PROJ-1"},
+		{"no parent issues", `{"parent":{"issues":[]}}`, ""},
+		{"no parent field", `{}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issue Issue
+			if err := json.Unmarshal([]byte(tt.json), &issue); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := issue.ParentID(); got != tt.expected {
+				t.Errorf("ParentID() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
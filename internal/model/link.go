@@ -0,0 +1,60 @@
+package model
+
+// IssueLinkType is a kind of relation between issues, e.g. "Depend" with
+// the phrases "depends on" / "is required for".
+type IssueLinkType struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	SourceToTarget string `json:"sourceToTarget"`
+	TargetToSource string `json:"targetToSource"`
+	Directed       bool   `json:"directed"`
+}
+
+// IssueLink groups the issues linked to an issue by one link type in one
+// direction ("OUTWARD", "INWARD" or "BOTH" for undirected types).
+type IssueLink struct {
+	ID        string         `json:"id"`
+	Direction string         `json:"direction"`
+	LinkType  *IssueLinkType `json:"linkType"`
+	Issues    []Issue        `json:"issues"`
+}
+
+// Phrase returns how the link reads from the issue that owns it,
+// e.g. "depends on" or "is required for".
+func (l IssueLink) Phrase() string {
+	if l.LinkType == nil {
+		return "linked"
+	}
+	phrase := l.LinkType.SourceToTarget
+	if l.Direction == "INWARD" {
+		phrase = l.LinkType.TargetToSource
+	}
+	if phrase == "" {
+		phrase = l.LinkType.Name
+	}
+	return phrase
+}
+
+// LinkDirection is one way of applying a link type, identified by the
+// link ID YouTrack uses in /api/issues/{id}/links/{linkID}.
+type LinkDirection struct {
+	LinkID string
+	Phrase string
+}
+
+// Directions lists the ways the type can link an issue to another.
+// Directed types have an outward ("s" suffix) and an inward ("t" suffix)
+// direction; undirected types have one.
+func (t IssueLinkType) Directions() []LinkDirection {
+	if !t.Directed {
+		phrase := t.SourceToTarget
+		if phrase == "" {
+			phrase = t.Name
+		}
+		return []LinkDirection{{LinkID: t.ID, Phrase: phrase}}
+	}
+	return []LinkDirection{
+		{LinkID: t.ID + "s", Phrase: t.SourceToTarget},
+		{LinkID: t.ID + "t", Phrase: t.TargetToSource},
+	}
+}
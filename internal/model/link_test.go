@@ -0,0 +1,48 @@
+package model
+
+import "testing"
+
+func TestIssueLink_Phrase(t *testing.T) {
+	depend := &IssueLinkType{ID: "74-0", Name: "Depend", SourceToTarget: "depends on", TargetToSource: "is required for", Directed: true}
+	relates := &IssueLinkType{ID: "74-1", Name: "Relates", SourceToTarget: "relates to", TargetToSource: "relates to"}
+
+	tests := []struct {
+		name string
+		link IssueLink
+		want string
+	}{
+		{"outward", IssueLink{Direction: "OUTWARD", LinkType: depend}, "depends on"},
+		{"inward", IssueLink{Direction: "INWARD", LinkType: depend}, "is required for"},
+		{"undirected", IssueLink{Direction: "BOTH", LinkType: relates}, "relates to"},
+		{"no phrase", IssueLink{Direction: "BOTH", LinkType: &IssueLinkType{Name: "Custom"}}, "Custom"},
+		{"no type", IssueLink{}, "linked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.Phrase(); got != tt.want {
+				t.Errorf("Phrase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueLinkType_Directions(t *testing.T) {
+	depend := IssueLinkType{ID: "74-0", SourceToTarget: "depends on", TargetToSource: "is required for", Directed: true}
+	dirs := depend.Directions()
+	if len(dirs) != 2 {
+		t.Fatalf("got %d directions, want 2", len(dirs))
+	}
+	if dirs[0].LinkID != "74-0s" || dirs[0].Phrase != "depends on" {
+		t.Errorf("unexpected outward direction: %+v", dirs[0])
+	}
+	if dirs[1].LinkID != "74-0t" || dirs[1].Phrase != "is required for" {
+		t.Errorf("unexpected inward direction: %+v", dirs[1])
+	}
+
+	relates := IssueLinkType{ID: "74-1", Name: "Relates"}
+	dirs = relates.Directions()
+	if len(dirs) != 1 || dirs[0].LinkID != "74-1" || dirs[0].Phrase != "Relates" {
+		t.Errorf("unexpected undirected directions: %+v", dirs)
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+// SavedQuery is a named search saved on the YouTrack server.
+type SavedQuery struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
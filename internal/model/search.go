@@ -0,0 +1,61 @@
+package model
+
+// SearchAssist is the server's analysis of a partly typed search query:
+// completions for the text around the caret and the styled parts of the
+// query, including syntax errors.
+type SearchAssist struct {
+	Query       string             `json:"query"`
+	Caret       int                `json:"caret"`
+	Suggestions []SearchSuggestion `json:"suggestions"`
+	StyleRanges []StyleRange       `json:"styleRanges"`
+}
+
+// SearchSuggestion completes a query: the text from CompletionStart to
+// CompletionEnd is replaced by Prefix, Option and Suffix. Offsets count
+// characters of the query.
+type SearchSuggestion struct {
+	Option          string `json:"option"`
+	Prefix          string `json:"prefix"`
+	Suffix          string `json:"suffix"`
+	Description     string `json:"description"`
+	CompletionStart int    `json:"completionStart"`
+	CompletionEnd   int    `json:"completionEnd"`
+	MatchingStart   int    `json:"matchingStart"` // part of Option matching the typed text
+	MatchingEnd     int    `json:"matchingEnd"`
+	Caret           int    `json:"caret"` // caret position once applied
+}
+
+// StyleRange marks how a part of the query was understood, e.g. as a
+// "field", "keyword" or "error".
+type StyleRange struct {
+	Start  int    `json:"start"`
+	Length int    `json:"length"`
+	Style  string `json:"style"`
+}
+
+// Apply returns query with the suggestion applied and the caret position
+// after it. Offsets outside the query are clamped.
+func (s SearchSuggestion) Apply(query string) (string, int) {
+	runes := []rune(query)
+	start := min(max(s.CompletionStart, 0), len(runes))
+	end := min(max(s.CompletionEnd, start), len(runes))
+	insert := []rune(s.Prefix + s.Option + s.Suffix)
+
+	result := string(runes[:start]) + string(insert) + string(runes[end:])
+	caret := s.Caret
+	if caret <= 0 || caret > len([]rune(result)) {
+		caret = start + len(insert)
+	}
+	return result, caret
+}
+
+// Errors returns the parts of the query the server could not parse.
+func (a *SearchAssist) Errors() []StyleRange {
+	var errs []StyleRange
+	for _, r := range a.StyleRanges {
+		if r.Style == "error" {
+			errs = append(errs, r)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,55 @@
+package model
+
+import "testing"
+
+func TestSearchSuggestion_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		s         SearchSuggestion
+		want      string
+		wantCaret int
+	}{
+		{
+			name:  "complete field",
+			query: "sta",
+			s:     SearchSuggestion{Option: "State", Suffix: ": ", CompletionStart: 0, CompletionEnd: 3, Caret: 7},
+			want:  "State: ", wantCaret: 7,
+		},
+		{
+			name:  "complete value in the middle",
+			query: "State: op #Bug",
+			s:     SearchSuggestion{Option: "Open", Suffix: " ", CompletionStart: 7, CompletionEnd: 9, Caret: 12},
+			want:  "State: Open  #Bug", wantCaret: 12,
+		},
+		{
+			name:  "braces for names with spaces",
+			query: "State: in",
+			s:     SearchSuggestion{Option: "In Progress", Prefix: "{", Suffix: "} ", CompletionStart: 7, CompletionEnd: 9},
+			want:  "State: {In Progress} ", wantCaret: 21,
+		},
+		{
+			name:  "offsets past the end",
+			query: "é",
+			s:     SearchSuggestion{Option: "éa", CompletionStart: 0, CompletionEnd: 5},
+			want:  "éa", wantCaret: 2,
+		},
+	}
+	for _, tt := range tests {
+		got, caret := tt.s.Apply(tt.query)
+		if got != tt.want || caret != tt.wantCaret {
+			t.Errorf("%s: got %q caret %d, want %q caret %d", tt.name, got, caret, tt.want, tt.wantCaret)
+		}
+	}
+}
+
+func TestSearchAssist_Errors(t *testing.T) {
+	a := SearchAssist{StyleRanges: []StyleRange{
+		{Start: 0, Length: 5, Style: "field"},
+		{Start: 7, Length: 3, Style: "error"},
+	}}
+	errs := a.Errors()
+	if len(errs) != 1 || errs[0].Start != 7 {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+}
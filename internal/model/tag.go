@@ -0,0 +1,16 @@
+package model
+
+// Tag is a label that can be applied to issues. Tags are shared across
+// projects and may be owned by a user.
+type Tag struct {
+	ID    string      `json:"id"`
+	Name  string      `json:"name"`
+	Color *FieldStyle `json:"color"`
+}
+
+// FieldStyle is the color pair YouTrack assigns to tags and field values.
+// Colors are hex strings such as "#e6ecf5"; either may be empty.
+type FieldStyle struct {
+	Background string `json:"background"`
+	Foreground string `json:"foreground"`
+}
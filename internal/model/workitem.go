@@ -0,0 +1,137 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Default YouTrack time tracking settings: 8-hour days, 5-day weeks.
+const (
+	minutesPerHour = 60
+	minutesPerDay  = 8 * minutesPerHour
+	minutesPerWeek = 5 * minutesPerDay
+)
+
+// WorkItem is a block of time logged against an issue.
+type WorkItem struct {
+	ID       string        `json:"id"`
+	Author   *User         `json:"author"`
+	Date     int64         `json:"date"`
+	Duration Period        `json:"duration"`
+	Text     string        `json:"text"`
+	Type     *WorkItemType `json:"type"`
+}
+
+// WorkItemType is a category of work, e.g. "Development" or "Testing".
+type WorkItemType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Period is a YouTrack duration value, as used by work items and
+// period custom fields such as "Estimation".
+type Period struct {
+	Minutes      int    `json:"minutes"`
+	Presentation string `json:"presentation,omitempty"`
+}
+
+// TotalMinutes sums the durations of work items.
+func TotalMinutes(items []WorkItem) int {
+	total := 0
+	for _, item := range items {
+		total += item.Duration.Minutes
+	}
+	return total
+}
+
+// FormatMinutes renders a duration as hours and minutes, e.g. "1h 30m".
+func FormatMinutes(minutes int) string {
+	if minutes <= 0 {
+		return "0m"
+	}
+	h, m := minutes/minutesPerHour, minutes%minutesPerHour
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
+}
+
+// ParseDuration parses a YouTrack-style duration such as "1h30m", "1h 30m",
+// "90m", "1.5h", "2d" or "1w" into minutes. A bare number means minutes, as
+// does a trailing one after other units ("1h30").
+// Days and weeks use YouTrack's defaults of 8 hours and 5 days.
+func ParseDuration(s string) (int, error) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("duration must be positive")
+		}
+		return n, nil
+	}
+
+	total := 0.0
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if i < 0 {
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += n
+			break
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		var unit float64
+		switch s[i] {
+		case 'w':
+			unit = minutesPerWeek
+		case 'd':
+			unit = minutesPerDay
+		case 'h':
+			unit = minutesPerHour
+		case 'm':
+			unit = 1
+		default:
+			return 0, fmt.Errorf("unknown unit %q in duration", s[i])
+		}
+		total += n * unit
+		s = s[i+1:]
+	}
+
+	minutes := int(total + 0.5)
+	if minutes <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return minutes, nil
+}
+
+// customFieldValuePeriod extracts the minutes of a period custom field value.
+// Returns false if the value is null or not a period.
+func customFieldValuePeriod(raw json.RawMessage) (int, bool) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, false
+	}
+	var p struct {
+		Minutes *int `json:"minutes"`
+	}
+	if err := json.Unmarshal(raw, &p); err != nil || p.Minutes == nil {
+		return 0, false
+	}
+	return *p.Minutes, true
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"1h30m", 90, false},
+		{"1h 30m", 90, false},
+		{"90m", 90, false},
+		{"45", 45, false},
+		{"2H", 120, false},
+		{"1.5h", 90, false},
+		{"1d", 480, false},
+		{"1w 1d", 2880, false},
+		{"1h30", 90, false},
+		{"1h 15", 75, false},
+		{"1h1.2.3", 0, true},
+		{"", 0, true},
+		{"0", 0, true},
+		{"h", 0, true},
+		{"3x", 0, true},
+		{"1h-", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	tests := map[int]string{0: "0m", 45: "45m", 60: "1h", 90: "1h 30m", 600: "10h"}
+	for in, want := range tests {
+		if got := FormatMinutes(in); got != want {
+			t.Errorf("FormatMinutes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTotalMinutes(t *testing.T) {
+	items := []WorkItem{{Duration: Period{Minutes: 30}}, {Duration: Period{Minutes: 90}}}
+	if got := TotalMinutes(items); got != 120 {
+		t.Errorf("TotalMinutes() = %d, want 120", got)
+	}
+}
+
+func TestIssue_PeriodValue(t *testing.T) {
+	var issue Issue
+	data := `{"customFields":[{"name":"Estimation","$type":"PeriodIssueCustomField","value":{"minutes":300,"presentation":"5h"}},{"name":"Spent time","$type":"PeriodIssueCustomField","value":null}]}`
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got, ok := issue.PeriodValue("Estimation"); !ok || got != 300 {
+		t.Errorf("PeriodValue(Estimation) = %d, %v; want 300, true", got, ok)
+	}
+	if _, ok := issue.PeriodValue("Spent time"); ok {
+		t.Error("PeriodValue(Spent time) should be false for a null value")
+	}
+	if _, ok := issue.PeriodValue("Missing"); ok {
+		t.Error("PeriodValue(Missing) should be false")
+	}
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +13,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/cf/lazytrack/internal/api"
 	"github.com/cf/lazytrack/internal/config"
 	"github.com/cf/lazytrack/internal/model"
 )
@@ -21,15 +24,25 @@ const (
 	listPane pane = iota
 	detailPane
 	commentsPane
+	historyPane
 )
 
 // issueItem wraps model.Issue for the list.Model interface.
+// In tree mode it also carries its position in the parent/subtask tree.
 type issueItem struct {
-	issue model.Issue
+	issue       model.Issue
+	tree        bool
+	depth       int
+	hasChildren bool
+	collapsed   bool
 }
 
 func (i issueItem) Title() string {
-	return fmt.Sprintf("[%s] %s", i.issue.IDReadable, i.issue.Summary)
+	title := fmt.Sprintf("[%s] %s", i.issue.IDReadable, i.issue.Summary)
+	if i.tree {
+		title = treePrefix(i.depth, i.hasChildren, i.collapsed) + title
+	}
+	return title
 }
 
 func (i issueItem) Description() string {
@@ -47,7 +60,11 @@ func (i issueItem) Description() string {
 		}
 		parts = append(parts, name)
 	}
-	return strings.Join(parts, " · ")
+	desc := strings.Join(parts, " · ")
+	if len(i.issue.Tags) > 0 {
+		desc += " " + renderTagChips(i.issue.Tags)
+	}
+	return desc
 }
 
 func (i issueItem) FilterValue() string {
@@ -56,26 +73,44 @@ func (i issueItem) FilterValue() string {
 
 type App struct {
 	service     IssueService
+	ctx         context.Context    // cancelled on quit; parent of every request context
+	cancel      context.CancelFunc
+	listCancel   context.CancelFunc // cancels the in-flight issue list fetch
+	detailCancel context.CancelFunc // cancels the in-flight issue detail fetch
+	historyCancel context.CancelFunc // cancels the in-flight activity fetch
+	finderCancel context.CancelFunc // cancels the in-flight finder search
+	assistCancel context.CancelFunc // cancels the in-flight query completion
+	pendingDetailID string          // issue whose detail fetch is in flight
 	focus       pane
 	list        list.Model
 	detail      viewport.Model
-	comments    viewport.Model
+	comments    CommentList
+	history     viewport.Model
 	issues      []model.Issue
 	selected    *model.Issue
 	query       string
 	err         string
+	notice      string // transient confirmation, cleared on the next key
+	authFailed  bool // err is a 401; enter re-runs setup
+	rerunSetup  bool // quit so main can run setup again
 	width       int
 	height      int
 	ready       bool
 	loading     bool
+	retry       *api.RetryEvent // latest retry notice for the request behind loading
 	pageSize    int
 	hasMore     bool
 	searchInput  textinput.Model
 	searching    bool
+	queryHistory queryHistory // past searches, recalled in the search prompt
+	queryAssist  QueryAssist  // completions for the search prompt
 	issueDialog IssueDialog
 	confirmDelete bool
 	commenting    bool
 	commentInput  textarea.Model
+	mentions      MentionPicker // @mention suggestions for commentInput
+	editingComment *model.Comment // comment being edited in commentInput; nil when adding
+	confirmCommentDelete bool
 	showHelp      bool
 	listCollapsed bool
 	listRatio     float64
@@ -85,6 +120,31 @@ type App struct {
 	assignInput   textinput.Model
 	finderDialog   FinderDialog
 	projectPicker  ProjectPickerDialog
+	attachmentDialog AttachmentDialog
+	openedFiles      []string // temp copies of opened attachments, removed on quit
+	linksDialog      LinksDialog
+	linkTypes        []model.IssueLinkType // cached on first use
+	workLogDialog    WorkLogDialog
+	workItems        []model.WorkItem                // work logged on the selected issue
+	workItemTypes    map[string][]model.WorkItemType // by project ID, cached on first use
+	projectFields    map[string][]model.ProjectCustomField // by project ID, for the detail field order
+	hideEmptyFields  bool                                  // detail view leaves out unset custom fields
+	showHistory      bool             // history replaces comments in the right column
+	showWork         bool             // detail lists work items; they are only fetched while shown
+	activities       []model.Activity // history of the selected issue, oldest first
+	tagDialog        TagDialog
+	board            BoardView
+	sprintPicker     SprintPickerDialog
+	savedSearches    SavedSearchDialog
+	cfg              *config.Config   // saved searches are written back to it
+	configPath       string
+	searchesViewed   map[string]int64 // saved search name → last opened, Unix ms
+	sprintBoard      *model.Agile  // board of activeSprint
+	activeSprint     *model.Sprint // sprint the issue list is scoped to
+	tags             []model.Tag                     // cached on first use
+	timer            *config.TimerState              // running work timer, persisted in state
+	timerGen         int
+	timerStopping    bool
 	activeProject  *model.Project
 	goingToIssue   bool
 	gotoInput      textinput.Model
@@ -100,6 +160,9 @@ type App struct {
 	filterBug          bool
 	filterTask         bool
 	leaderActive       bool
+	treeMode           bool            // list nests subtasks under their parents
+	collapsedIssues    map[string]bool // tree nodes whose subtasks are hidden
+	createParent       *model.Issue    // parent for the issue being created in tree mode
 }
 
 func NewApp(service IssueService, state config.State) *App {
@@ -114,7 +177,7 @@ func NewApp(service IssueService, state config.State) *App {
 	vp := viewport.New(0, 0)
 	vp.SetContent("Loading issues...")
 
-	cvp := viewport.New(0, 0)
+	hvp := viewport.New(0, 0)
 
 	si := textinput.New()
 	si.Placeholder = "YouTrack query (e.g., project: PROJ #Unresolved)"
@@ -145,11 +208,16 @@ func NewApp(service IssueService, state config.State) *App {
 		return nil
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	app := &App{
 		service:      service,
+		ctx:          ctx,
+		cancel:       cancel,
 		list:         l,
 		detail:       vp,
-		comments:     cvp,
+		comments:     NewCommentList(),
+		history:      hvp,
 		pageSize:     50,
 		listRatio:      state.UI.ListRatio,
 		listCollapsed:  state.UI.ListCollapsed,
@@ -162,11 +230,30 @@ func NewApp(service IssueService, state config.State) *App {
 		assignInput:  asi,
 		finderDialog:        NewFinderDialog(),
 		projectPicker:       NewProjectPickerDialog(),
+		attachmentDialog:    NewAttachmentDialog(),
+		linksDialog:         NewLinksDialog(),
+		workLogDialog:       NewWorkLogDialog(),
+		tagDialog:           NewTagDialog(),
+		board:               NewBoardView(),
+		sprintPicker:        NewSprintPickerDialog(),
+		savedSearches:       NewSavedSearchDialog(),
+		configPath:          config.DefaultPath(),
+		searchesViewed:      state.UI.SearchesViewed,
+		queryHistory:        newQueryHistory(state.QueryHistory),
+		workItemTypes:       map[string][]model.WorkItemType{},
+		projectFields:       map[string][]model.ProjectCustomField{},
+		hideEmptyFields:     state.UI.HideEmptyFields,
 		notifDialog:         NewNotificationDialog(),
 		lastCheckedMentions: state.UI.LastCheckedMentions,
+		treeMode:            state.UI.TreeMode,
+		timer:               state.Timer,
 		gotoInput:           gti,
 	}
 
+	if app.searchesViewed == nil {
+		app.searchesViewed = map[string]int64{}
+	}
+
 	// Restore active project from state
 	if state.UI.ActiveProject != "" {
 		app.activeProject = &model.Project{ShortName: state.UI.ActiveProject}
@@ -176,13 +263,26 @@ func NewApp(service IssueService, state config.State) *App {
 }
 
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(a.fetchIssuesCmd(), a.fetchCurrentUserCmd())
+	cmds := []tea.Cmd{a.fetchIssuesCmd(), a.fetchCurrentUserCmd()}
+	if a.timer != nil {
+		cmds = append(cmds, a.timerTickCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// A retry notice only describes the request that is currently loading.
+	if _, ok := msg.(api.RetryEvent); !ok && !a.loading {
+		a.retry = nil
+	}
+
 	switch msg := msg.(type) {
+	case api.RetryEvent:
+		a.retry = &msg
+		return a, nil
+
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
@@ -196,26 +296,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.loading = false
 		a.issues = msg.issues
 		a.hasMore = len(msg.issues) == a.pageSize
-		items := make([]list.Item, len(msg.issues))
-		for i, issue := range msg.issues {
-			items[i] = issueItem{issue: issue}
-		}
-		cmd := a.list.SetItems(items)
+		cmd := a.list.SetItems(a.listItems())
 		cmds = append(cmds, cmd)
 		if len(msg.issues) > 0 {
-			targetIdx := 0
-			targetID := msg.issues[0].IDReadable
-			if a.restoreIssueID != "" {
-				for i, issue := range msg.issues {
-					if issue.IDReadable == a.restoreIssueID {
-						targetIdx = i
-						targetID = issue.IDReadable
-						break
-					}
-				}
-				a.restoreIssueID = ""
+			if !a.selectListIssue(a.restoreIssueID) {
+				a.list.Select(0)
 			}
-			a.list.Select(targetIdx)
+			a.restoreIssueID = ""
+			targetID := a.list.SelectedItem().(issueItem).issue.IDReadable
 			cmds = append(cmds, a.fetchDetailCmd(targetID))
 		} else {
 			a.detail.SetContent("No issues found. Press space+c to create one or '/' to search.")
@@ -226,39 +314,120 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.loading = false
 		a.hasMore = len(msg.issues) == a.pageSize
 		a.issues = append(a.issues, msg.issues...)
-		items := make([]list.Item, len(a.issues))
-		for i, issue := range a.issues {
-			items[i] = issueItem{issue: issue}
-		}
-		cmd := a.list.SetItems(items)
-		return a, cmd
+		a.refreshListItems()
+		return a, nil
 
 	case issueDetailLoadedMsg:
 		a.err = ""
 		a.loading = false
+		a.pendingDetailID = ""
+		sameIssue := a.selected != nil && a.selected.IDReadable == msg.issue.IDReadable
 		a.selected = msg.issue
+		a.workItems = msg.workItems
 		a.resizePanels()
-		a.detail.SetContent(renderIssueDetail(msg.issue, a.detail.Width))
+		a.detail.SetContent(a.renderDetail())
 		a.detail.GotoTop()
-		if len(msg.issue.Comments) > 0 {
-			a.comments.SetContent(renderComments(msg.issue.Comments, a.comments.Width))
-			a.comments.GotoTop()
-		} else {
-			a.comments.SetContent("")
-			if a.focus == commentsPane {
-				a.focus = detailPane
+		a.comments.SetComments(msg.issue.Comments, !sameIssue)
+		if len(msg.issue.Comments) == 0 && a.focus == commentsPane {
+			a.focus = detailPane
+		}
+		var cmds []tea.Cmd
+		if p := msg.issue.Project; p != nil && p.ID != "" {
+			if _, ok := a.projectFields[p.ID]; !ok {
+				a.projectFields[p.ID] = nil // requested; don't ask again while in flight
+				cmds = append(cmds, a.fetchProjectFieldsCmd(p.ID))
 			}
 		}
+		if a.showHistory {
+			cmds = append(cmds, a.fetchHistoryCmd(msg.issue.IDReadable))
+		}
+		return a, tea.Batch(cmds...)
+
+	case agilesLoadedMsg:
+		a.loading = false
+		a.board.Open(msg.agiles, a.resolveGotoProject())
+		return a, a.boardCmd()
+
+	case boardSprintLoadedMsg:
+		a.loading = false
+		if agile := a.board.Agile(); agile != nil && agile.ID == msg.agileID {
+			a.board.SetSprint(msg.sprint)
+		}
+		return a, nil
+
+	case sprintBoardsLoadedMsg:
+		a.loading = false
+		issueID := ""
+		if a.selected != nil {
+			issueID = a.selected.IDReadable
+		}
+		a.sprintPicker.Open(msg.agiles, a.resolveGotoProject(), issueID)
+		return a, a.sprintPickerCmd()
+
+	case sprintsLoadedMsg:
+		a.loading = false
+		if agile := a.sprintPicker.Agile(); agile != nil && agile.ID == msg.agileID {
+			a.sprintPicker.SetSprints(msg.sprints)
+		}
+		return a, nil
+
+	case sprintIssueChangedMsg:
+		a.loading = false
+		if msg.removed {
+			a.notice = fmt.Sprintf("Removed %s from %s", msg.issueID, msg.sprint.Name)
+		} else {
+			a.notice = fmt.Sprintf("Added %s to %s", msg.issueID, msg.sprint.Name)
+		}
+		if a.activeSprint != nil && a.activeSprint.ID == msg.sprint.ID {
+			a.loading = true
+			return a, a.fetchIssuesCmd()
+		}
+		return a, nil
+
+	case savedQueriesLoadedMsg:
+		if !a.savedSearches.active {
+			return a, nil
+		}
+		a.savedSearches.AddRemote(msg.queries)
+		return a, a.savedSearchCountsCmd()
+
+	case savedSearchCountMsg:
+		a.savedSearches.SetCount(msg.name, msg.total, msg.unread, msg.failed)
+		return a, nil
+
+	case projectFieldsLoadedMsg:
+		if msg.retry {
+			delete(a.projectFields, msg.projectID)
+			return a, nil
+		}
+		a.projectFields[msg.projectID] = msg.fields
+		if a.selected != nil && a.selected.Project != nil && a.selected.Project.ID == msg.projectID {
+			a.reRenderContent()
+		}
+		return a, nil
+
+	case historyLoadedMsg:
+		if a.selected == nil || a.selected.IDReadable != msg.issueID {
+			return a, nil
+		}
+		if msg.err != nil {
+			a.history.SetContent(errorStyle.UnsetPadding().Render("Couldn't load history: " + describeError(msg.err)))
+			return a, nil
+		}
+		a.activities = msg.activities
+		a.history.SetContent(renderHistory(a.activities, a.history.Width))
+		a.history.GotoBottom()
 		return a, nil
 
 	case projectsLoadedMsg:
 		a.loading = false
-		cmd := a.issueDialog.OpenCreate(msg.projects)
+		cmd := a.issueDialog.OpenCreate(msg.projects, a.createParent)
 		if len(msg.projects) > 0 {
-			projectID := msg.projects[0].ID
+			projectID := msg.projects[a.issueDialog.projectIndex].ID
 			service := a.service
+			ctx := a.ctx
 			return a, tea.Batch(cmd, func() tea.Msg {
-				fields, err := service.ListProjectCustomFields(projectID)
+				fields, err := service.ListProjectCustomFields(ctx, projectID)
 				if err != nil {
 					return errMsg{err}
 				}
@@ -279,13 +448,26 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case queryAssistDebounceMsg:
+		if a.searching && msg.generation == a.queryAssist.gen {
+			return a, a.queryAssistCmd(msg.generation)
+		}
+		return a, nil
+
+	case queryAssistResultsMsg:
+		if a.searching {
+			a.queryAssist.SetResults(msg.assist, msg.generation)
+		}
+		return a, nil
+
 	case assigneeDebounceMsg:
 		if a.issueDialog.active && msg.generation == a.issueDialog.assigneeGen {
 			query := a.issueDialog.assigneeInput.Value()
 			service := a.service
+			ctx := a.ctx
 			gen := msg.generation
 			return a, func() tea.Msg {
-				users, err := service.SearchUsers(query)
+				users, err := service.SearchUsers(ctx, query)
 				if err != nil {
 					return errMsg{err}
 				}
@@ -300,8 +482,31 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case mentionDebounceMsg:
+		if p := a.mentionPicker(); p != nil && msg.generation == p.gen {
+			query := p.query
+			service := a.service
+			ctx := a.ctx
+			gen := msg.generation
+			return a, func() tea.Msg {
+				users, err := service.SearchUsers(ctx, query)
+				if err != nil {
+					return errMsg{err}
+				}
+				return mentionSearchResultsMsg{users: users, generation: gen}
+			}
+		}
+		return a, nil
+
+	case mentionSearchResultsMsg:
+		if p := a.mentionPicker(); p != nil {
+			p.SetResults(msg.users, msg.generation)
+		}
+		return a, nil
+
 	case issueCreatedMsg:
 		a.loading = false
+		a.notice = msg.notice
 		return a, a.fetchIssuesCmd()
 
 	case issueUpdatedMsg:
@@ -315,9 +520,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case issueDeletedMsg:
 		a.loading = false
 		a.selected = nil
+		a.workItems = nil
+		a.activities = nil
 		a.detail.SetContent("Issue deleted.")
-		a.comments.SetContent("")
-		if a.focus == commentsPane {
+		a.comments.SetComments(nil, true)
+		if a.focus == commentsPane || a.focus == historyPane {
 			a.focus = detailPane
 		}
 		a.resizePanels()
@@ -331,26 +538,185 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case commentUpdatedMsg:
+		a.loading = false
+		a.notice = "Comment updated"
+		if a.selected != nil {
+			return a, a.fetchDetailCmd(a.selected.IDReadable)
+		}
+		return a, nil
+
+	case commentDeletedMsg:
+		a.loading = false
+		a.notice = "Comment deleted"
+		if a.selected != nil {
+			return a, a.fetchDetailCmd(a.selected.IDReadable)
+		}
+		return a, nil
+
+	case commentEditorFinishedMsg:
+		if msg.tempPath != "" {
+			defer os.Remove(msg.tempPath)
+		}
+		if msg.err != nil {
+			a.err = "Editor error: " + msg.err.Error()
+			return a, nil
+		}
+		text, err := readCommentTempFile(msg.tempPath)
+		if err != nil {
+			a.err = "Read error: " + err.Error()
+			return a, nil
+		}
+		if text == "" || text == strings.TrimSpace(msg.initial) {
+			return a, nil // emptied or unchanged: nothing to post
+		}
+		a.loading = true
+		if msg.comment == nil {
+			return a, a.addCommentCmd(msg.issueID, text)
+		}
+		return a, a.updateCommentCmd(msg.issueID, msg.comment.ID, text)
+
+	case linkTypesLoadedMsg:
+		a.linkTypes = msg.types
+		if a.linksDialog.active {
+			a.linksDialog.SetLinkTypes(msg.types)
+		}
+		return a, nil
+
+	case workItemTypesLoadedMsg:
+		a.workItemTypes[msg.projectID] = msg.types
+		if a.workLogDialog.active && a.workLogDialog.projectID == msg.projectID {
+			a.workLogDialog.SetTypes(msg.types)
+		}
+		return a, nil
+
+	case tagsLoadedMsg:
+		a.tags = msg.tags
+		if a.tags == nil {
+			a.tags = []model.Tag{}
+		}
+		if a.tagDialog.active {
+			a.tagDialog.SetTags(a.tags)
+		}
+		return a, nil
+
+	case tagsChangedMsg:
+		a.loading = false
+		for i := range a.issues {
+			if a.issues[i].IDReadable == msg.issueID {
+				a.issues[i].Tags = msg.tags
+			}
+		}
+		a.refreshListItems()
+		if a.selected != nil && a.selected.IDReadable == msg.issueID {
+			return a, a.fetchDetailCmd(msg.issueID)
+		}
+		return a, nil
+
+	case timerTickMsg:
+		if a.timer == nil || msg.gen != a.timerGen {
+			return a, nil
+		}
+		return a, a.timerTickCmd()
+
+	case workItemsLoadedMsg:
+		if !a.showWork || a.selected == nil || a.selected.IDReadable != msg.issueID {
+			return a, nil
+		}
+		a.workItems = msg.workItems
+		a.detail.SetContent(a.renderDetail())
+		return a, nil
+
+	case workLoggedMsg:
+		a.loading = false
+		if msg.fromTimer {
+			a.timer = nil
+			a.timerStopping = false
+			a.saveState()
+		}
+		a.notice = fmt.Sprintf("Logged %s on %s", model.FormatMinutes(msg.minutes), msg.issueID)
+		if a.selected != nil && a.selected.IDReadable == msg.issueID {
+			return a, a.fetchDetailCmd(msg.issueID)
+		}
+		return a, nil
+
+	case linksChangedMsg:
+		a.loading = false
+		if a.selected != nil {
+			return a, a.fetchDetailCmd(a.selected.IDReadable)
+		}
+		return a, nil
+
+	case attachmentOpenedMsg:
+		a.loading = false
+		a.openedFiles = append(a.openedFiles, msg.path)
+		return a, nil
+
+	case attachmentSavedMsg:
+		a.loading = false
+		a.notice = "Saved " + msg.path
+		return a, nil
+
+	case attachmentUploadedMsg:
+		a.loading = false
+		a.notice = "Uploaded " + msg.name
+		if a.selected != nil {
+			return a, a.fetchDetailCmd(a.selected.IDReadable)
+		}
+		return a, nil
+
 	case errMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			return a, nil // superseded request; its replacement owns the loading state
+		}
 		a.loading = false
+		a.pendingDetailID = ""
+		a.timerStopping = false
+		a.board.SetError()
+		if api.IsUnauthorized(msg.err) {
+			a.authFailed = true
+			a.err = describeError(msg.err) + " — press enter to re-run setup"
+			return a, nil
+		}
 		if a.notifDialog.active {
-			a.notifDialog.SetError(msg.err.Error())
+			a.notifDialog.SetError(describeError(msg.err))
 			return a, nil
 		}
 		if a.finderDialog.active {
-			a.finderDialog.SetError(msg.err.Error())
+			a.finderDialog.SetError(describeError(msg.err))
+			return a, nil
+		}
+		if a.linksDialog.active {
+			a.linksDialog.SetError(describeError(msg.err))
+			return a, nil
+		}
+		if a.workLogDialog.active {
+			a.workLogDialog.SetError(describeError(msg.err))
+			return a, nil
+		}
+		if a.tagDialog.active {
+			a.tagDialog.SetError(describeError(msg.err))
 			return a, nil
 		}
-		a.err = msg.err.Error()
+		if a.sprintPicker.active {
+			a.sprintPicker.SetError(describeError(msg.err))
+			return a, nil
+		}
+		if a.savedSearches.active {
+			a.savedSearches.SetError(describeError(msg.err))
+			return a, nil
+		}
+		a.err = describeError(msg.err)
 		return a, nil
 
 	case finderDebounceMsg:
 		if a.finderDialog.active && msg.generation == a.finderDialog.searchGen {
 			query := a.finderDialog.Query()
 			service := a.service
+			ctx := a.supersede(&a.finderCancel)
 			gen := msg.generation
 			return a, func() tea.Msg {
-				issues, err := service.ListIssues(query, 0, 20)
+				issues, err := service.ListIssues(ctx, query, 0, 20)
 				if err != nil {
 					return errMsg{err}
 				}
@@ -399,27 +765,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.err = "Editor error: " + msg.err.Error()
 			return a, nil
 		}
-		parsed, err := parseIssueTempFile(msg.tempPath)
+		data, err := os.ReadFile(msg.tempPath)
 		if err != nil {
-			a.err = "Parse error: " + err.Error()
+			a.err = "Read error: " + err.Error()
 			return a, nil
 		}
-		fields := buildEditorUpdateFields(msg.original, parsed)
-		if fields == nil {
-			return a, nil // nothing changed
+		content := strings.TrimLeft(stripEditorHeader(string(data)), "\n")
+		if strings.TrimSpace(content) == "" {
+			return a, nil // emptied: cancel
+		}
+		parsed, err := parseIssueDraft(content)
+		switch {
+		case err != nil:
+			return a, a.openIssueEditor(msg.original, content, err.Error())
+		case msg.original == nil && parsed.project == "":
+			return a, a.openIssueEditor(nil, content, "project is required")
+		case parsed.summary == "":
+			return a, a.openIssueEditor(msg.original, content, "summary is required")
 		}
-		issueID := msg.original.IDReadable
-		service := a.service
 		a.loading = true
-		return a, func() tea.Msg {
-			err := service.UpdateIssue(issueID, fields)
-			if err != nil {
-				return errMsg{err}
-			}
-			return issueUpdatedMsg{}
+		if msg.original == nil {
+			return a, a.createFromEditorCmd(parsed, content)
 		}
+		return a, a.updateFromEditorCmd(msg.original, parsed, content)
+
+	case issueDraftReadyMsg:
+		a.loading = false
+		return a, editIssueDraft(msg.tempPath, msg.original)
+
+	case issueDraftRejectedMsg:
+		a.loading = false
+		return a, a.openIssueEditor(msg.original, msg.content, msg.problem)
 
 	case tea.KeyMsg:
+		a.notice = ""
 		if m, cmd := a.handleKeyMsg(msg); m != nil {
 			return m, cmd
 		}
@@ -433,20 +812,20 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.list, cmd = a.list.Update(msg)
 		cmds = append(cmds, cmd)
 
-		// Auto-load detail when cursor moves (with loading guard)
-		if !a.loading {
-			if item, ok := a.list.SelectedItem().(issueItem); ok {
-				if a.selected == nil || a.selected.IDReadable != item.issue.IDReadable {
-					a.loading = true
-					cmds = append(cmds, a.fetchDetailCmd(item.issue.IDReadable))
-				}
+		// Auto-load detail when cursor moves. A newer selection supersedes
+		// (cancels) the detail fetch still in flight for the previous one.
+		if item, ok := a.list.SelectedItem().(issueItem); ok {
+			issueID := item.issue.IDReadable
+			if (a.selected == nil || a.selected.IDReadable != issueID) && a.pendingDetailID != issueID {
+				a.loading = true
+				cmds = append(cmds, a.fetchDetailCmd(issueID))
 			}
 		}
 
 		// Pagination: load more when near bottom (with loading guard)
 		if !a.loading && a.hasMore {
 			idx := a.list.Index()
-			total := len(a.issues)
+			total := len(a.list.Items())
 			if total > 0 && idx >= total-5 {
 				a.loading = true
 				cmds = append(cmds, a.fetchMoreIssuesCmd())
@@ -460,6 +839,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case commentsPane:
 		a.comments, cmd = a.comments.Update(msg)
 		cmds = append(cmds, cmd)
+
+	case historyPane:
+		a.history, cmd = a.history.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	return a, tea.Batch(cmds...)
@@ -469,8 +852,31 @@ func (a *App) reRenderContent() {
 	if a.selected == nil {
 		return
 	}
-	a.detail.SetContent(renderIssueDetail(a.selected, a.detail.Width))
-	if len(a.selected.Comments) > 0 {
-		a.comments.SetContent(renderComments(a.selected.Comments, a.comments.Width))
+	a.detail.SetContent(a.renderDetail())
+	a.comments.SetComments(a.selected.Comments, false)
+	if a.showHistory && a.activities != nil {
+		a.history.SetContent(renderHistory(a.activities, a.history.Width))
+	}
+}
+
+// renderDetail renders the selected issue for the detail pane, with its
+// custom fields in the project's order once that is known.
+func (a *App) renderDetail() string {
+	fields := fieldsLayout{hideEmpty: a.hideEmptyFields}
+	if p := a.selected.Project; p != nil {
+		fields.order = a.projectFields[p.ID]
 	}
+	return renderIssueDetail(a.selected, a.workItems, fields, a.detail.Width)
+}
+
+// RerunSetup reports whether the app quit because the user asked to
+// re-run setup after an authentication failure.
+func (a *App) RerunSetup() bool {
+	return a.rerunSetup
+}
+
+// SetConfig gives the app the loaded config, whose saved searches it lists
+// and updates.
+func (a *App) SetConfig(cfg *config.Config) {
+	a.cfg = cfg
 }
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type attachmentAction int
+
+const (
+	attachmentOpen attachmentAction = iota
+	attachmentSave
+	attachmentUpload
+)
+
+// AttachmentDialog is a centered popup listing an issue's attachments.
+// It doubles as the path prompt for saving and uploading files.
+type AttachmentDialog struct {
+	issueID     string
+	attachments []model.Attachment
+	cursor      int
+	active      bool
+	submitted   bool
+	prompting   bool // pathInput has focus (save or upload)
+	fromList    bool // esc in the path prompt returns to the list
+	action      attachmentAction
+	selected    *model.Attachment
+	path        string
+	pathInput   textinput.Model
+	err         string
+}
+
+func NewAttachmentDialog() AttachmentDialog {
+	pi := textinput.New()
+	pi.Prompt = "Path: "
+	pi.CharLimit = 4096
+	return AttachmentDialog{pathInput: pi}
+}
+
+// Open activates the dialog listing the given attachments.
+func (d *AttachmentDialog) Open(issueID string, attachments []model.Attachment) {
+	d.issueID = issueID
+	d.attachments = attachments
+	d.cursor = 0
+	d.active = true
+	d.submitted = false
+	d.prompting = false
+	d.fromList = true
+	d.selected = nil
+	d.path = ""
+	d.err = ""
+	d.pathInput.Blur()
+}
+
+// OpenUpload activates the dialog directly in the upload path prompt.
+func (d *AttachmentDialog) OpenUpload(issueID string) tea.Cmd {
+	d.Open(issueID, nil)
+	d.fromList = false
+	return d.startUpload()
+}
+
+func (d *AttachmentDialog) Close() {
+	d.active = false
+	d.prompting = false
+	d.pathInput.Blur()
+}
+
+func (d *AttachmentDialog) startUpload() tea.Cmd {
+	d.action = attachmentUpload
+	d.prompting = true
+	d.err = ""
+	d.pathInput.Placeholder = "File to upload"
+	d.pathInput.SetValue("")
+	return d.pathInput.Focus()
+}
+
+func (d *AttachmentDialog) startSave() tea.Cmd {
+	att := d.attachments[d.cursor]
+	d.selected = &att
+	d.action = attachmentSave
+	d.prompting = true
+	d.err = ""
+	d.pathInput.Placeholder = "Destination"
+	d.pathInput.SetValue(defaultSavePath(att.Name))
+	d.pathInput.CursorEnd()
+	return d.pathInput.Focus()
+}
+
+func (d *AttachmentDialog) Update(msg tea.Msg) (AttachmentDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+
+	if d.prompting {
+		switch keyMsg.String() {
+		case "esc":
+			if d.fromList {
+				d.prompting = false
+				d.pathInput.Blur()
+			} else {
+				d.Close()
+			}
+			return *d, nil
+		case "enter":
+			path := strings.TrimSpace(d.pathInput.Value())
+			if path == "" {
+				d.err = "Path is required"
+				return *d, nil
+			}
+			d.path = expandHome(path)
+			d.submitted = true
+			d.Close()
+			return *d, nil
+		}
+		var cmd tea.Cmd
+		d.pathInput, cmd = d.pathInput.Update(msg)
+		return *d, cmd
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		d.Close()
+	case "enter", "o":
+		if len(d.attachments) > 0 {
+			att := d.attachments[d.cursor]
+			d.selected = &att
+			d.action = attachmentOpen
+			d.submitted = true
+			d.Close()
+		}
+	case "s":
+		if len(d.attachments) > 0 {
+			return *d, d.startSave()
+		}
+	case "u":
+		return *d, d.startUpload()
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.attachments)-1 {
+			d.cursor++
+		}
+	}
+	return *d, nil
+}
+
+func (d *AttachmentDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width * 3 / 5
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	contentWidth := dialogWidth - 6
+
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Attachments — "+d.issueID) + "\n\n")
+
+	if len(d.attachments) == 0 && d.fromList {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("No attachments") + "\n")
+	}
+
+	normalStyle := lipgloss.NewStyle().Width(contentWidth)
+	selectedStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Background(lipgloss.Color("237")).
+		Foreground(lipgloss.Color("255"))
+
+	for i, att := range d.attachments {
+		size := formatSize(att.Size)
+		name := att.Name
+		if maxName := contentWidth - len(size) - 4; lipgloss.Width(name) > maxName && maxName > 1 {
+			name = ansiTruncate(name, maxName-1) + "…"
+		}
+		line := fmt.Sprintf("%s %s  %s", iconAttachment, name, hintDescStyle.Render(size))
+		if i == d.cursor && !d.prompting {
+			b.WriteString(selectedStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(normalStyle.Render(line) + "\n")
+		}
+	}
+
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	if d.prompting {
+		if d.fromList {
+			b.WriteString("\n")
+		}
+		b.WriteString(d.pathInput.View() + "\n")
+		if d.err != "" {
+			b.WriteString(errorStyle.Render(d.err) + "\n")
+		}
+		b.WriteString("\n")
+		verb := "save"
+		if d.action == attachmentUpload {
+			verb = "upload"
+		}
+		b.WriteString(hint.Render("enter: " + verb + "  esc: cancel"))
+	} else {
+		b.WriteString("\n")
+		b.WriteString(hint.Render("j/k: navigate  enter/o: open  s: save  u: upload  esc: close"))
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// defaultSavePath suggests the XDG download directory for a file name.
+func defaultSavePath(name string) string {
+	dir := xdg.UserDirs.Download
+	if dir == "" {
+		dir = "."
+	}
+	return filepath.Join(dir, filepath.Base(name))
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
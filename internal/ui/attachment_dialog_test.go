@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// attachmentService serves fixed file content and records uploads.
+type attachmentService struct {
+	mockService
+	content  string
+	uploaded map[string]string
+}
+
+func (s *attachmentService) DownloadAttachment(ctx context.Context, att model.Attachment, w io.Writer) error {
+	_, err := io.WriteString(w, s.content)
+	return err
+}
+
+func (s *attachmentService) UploadAttachment(ctx context.Context, issueID, name string, r io.Reader) ([]model.Attachment, error) {
+	data, _ := io.ReadAll(r)
+	if s.uploaded == nil {
+		s.uploaded = map[string]string{}
+	}
+	s.uploaded[issueID+"/"+name] = string(data)
+	return []model.Attachment{{Name: name}}, nil
+}
+
+func testAttachments() []model.Attachment {
+	return []model.Attachment{
+		{ID: "8-1", Name: "crash.log", Size: 2048},
+		{ID: "8-2", Name: "screen.png", Size: 5 << 20},
+	}
+}
+
+func TestAttachmentDialog_OpenSelected(t *testing.T) {
+	d := NewAttachmentDialog()
+	d.Open("PROJ-1", testAttachments())
+
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !d.submitted || d.active {
+		t.Fatal("expected submitted and closed after enter")
+	}
+	if d.action != attachmentOpen {
+		t.Errorf("got action %v, want attachmentOpen", d.action)
+	}
+	if d.selected == nil || d.selected.Name != "screen.png" {
+		t.Errorf("got selected %+v, want screen.png", d.selected)
+	}
+}
+
+func TestAttachmentDialog_SavePromptsForPath(t *testing.T) {
+	d := NewAttachmentDialog()
+	d.Open("PROJ-1", testAttachments())
+
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !d.prompting {
+		t.Fatal("expected path prompt after s")
+	}
+	if !strings.HasSuffix(d.pathInput.Value(), "crash.log") {
+		t.Errorf("expected default path to end in file name, got %q", d.pathInput.Value())
+	}
+
+	// esc returns to the list rather than closing
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !d.active || d.prompting {
+		t.Error("expected esc to return to the list")
+	}
+}
+
+func TestAttachmentDialog_UploadRequiresPath(t *testing.T) {
+	d := NewAttachmentDialog()
+	d.OpenUpload("PROJ-1")
+
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if d.submitted {
+		t.Error("expected no submit with empty path")
+	}
+	if d.err == "" {
+		t.Error("expected validation error")
+	}
+
+	// esc closes when opened directly for upload
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.active {
+		t.Error("expected dialog closed")
+	}
+}
+
+func TestAttachments_SaveWritesFile(t *testing.T) {
+	svc := &attachmentService{content: "log contents"}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.selected = &model.Issue{IDReadable: "PROJ-1", Attachments: testAttachments()}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if !app.attachmentDialog.active {
+		t.Fatal("expected attachment dialog after space o")
+	}
+
+	dest := filepath.Join(t.TempDir(), "out.log")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	app.attachmentDialog.pathInput.SetValue(dest)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected download cmd")
+	}
+	app.Update(cmd())
+
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading saved file: %v", err)
+	}
+	if string(data) != "log contents" {
+		t.Errorf("got %q, want %q", data, "log contents")
+	}
+	if !strings.Contains(app.notice, dest) {
+		t.Errorf("expected notice naming %s, got %q", dest, app.notice)
+	}
+}
+
+func TestAttachments_OpenUsesOpener(t *testing.T) {
+	var opened string
+	orig := startOpener
+	startOpener = func(target string) error { opened = target; return nil }
+	defer func() { startOpener = orig }()
+
+	svc := &attachmentService{content: "png"}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+
+	msg := app.downloadAttachmentCmd(testAttachments()[1], "", true)()
+	if _, ok := msg.(attachmentOpenedMsg); !ok {
+		t.Fatalf("got %T, want attachmentOpenedMsg", msg)
+	}
+	defer os.Remove(opened)
+	base := filepath.Base(opened)
+	if !strings.HasPrefix(base, "lazytrack-8-2-") || !strings.HasSuffix(base, "-screen.png") {
+		t.Errorf("unexpected temp path %q", opened)
+	}
+
+	app.Update(msg)
+	app.quit()
+	if _, err := os.Stat(opened); !os.IsNotExist(err) {
+		t.Errorf("expected %s removed on quit, stat err %v", opened, err)
+	}
+}
+
+func TestAttachments_SaveRefusesOverwrite(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "out.log")
+	os.WriteFile(dest, []byte("keep me"), 0644)
+
+	svc := &attachmentService{content: "log contents"}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+
+	msg := app.downloadAttachmentCmd(testAttachments()[0], dest, false)()
+	if _, ok := msg.(errMsg); !ok {
+		t.Fatalf("got %T, want errMsg", msg)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "keep me" {
+		t.Errorf("existing file overwritten: %q", data)
+	}
+}
+
+func TestAttachments_Upload(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "notes.txt")
+	os.WriteFile(src, []byte("hello"), 0644)
+
+	svc := &attachmentService{}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.selected = &model.Issue{IDReadable: "PROJ-1"}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	app.attachmentDialog.pathInput.SetValue(src)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected upload cmd")
+	}
+	app.Update(cmd())
+
+	if svc.uploaded["PROJ-1/notes.txt"] != "hello" {
+		t.Errorf("unexpected uploads: %v", svc.uploaded)
+	}
+	if app.notice != "Uploaded notes.txt" {
+		t.Errorf("got notice %q", app.notice)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:       "0 B",
+		1023:    "1023 B",
+		2048:    "2.0 KB",
+		5 << 20: "5.0 MB",
+	}
+	for n, want := range tests {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
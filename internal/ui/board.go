@@ -0,0 +1,497 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type swimlaneMode int
+
+const (
+	swimlaneNone swimlaneMode = iota
+	swimlaneAssignee
+	swimlaneParent
+	swimlaneModeCount
+)
+
+func (m swimlaneMode) String() string {
+	switch m {
+	case swimlaneAssignee:
+		return "assignee"
+	case swimlaneParent:
+		return "parent"
+	default:
+		return "none"
+	}
+}
+
+// minBoardColumnWidth is the narrowest a column gets; columns that don't
+// fit scroll into view with the focus.
+const minBoardColumnWidth = 24
+
+// BoardView shows the current sprint of an agile board as columns of issue
+// cards, optionally split into swimlanes.
+type BoardView struct {
+	agiles     []model.Agile
+	agileIndex int
+	sprint     *model.Sprint
+	swimlanes  swimlaneMode
+	col        int    // focused column
+	selected   string // readable ID of the selected card
+	offset     int    // first body line shown
+	active     bool
+	loading    bool
+
+	sprintRequested bool // app should load the sprint of Agile() and call SetSprint
+	moveRequested   bool // app should move moveIssue to column moveColumn
+	moveIssue       *model.Issue
+	moveColumn      int
+	submitted       bool // enter on a card; open selected in the detail view
+}
+
+func NewBoardView() BoardView {
+	return BoardView{}
+}
+
+// Open shows the first board that includes the given project, or the first
+// board when none does, and requests its current sprint.
+func (b *BoardView) Open(agiles []model.Agile, project string) {
+	b.agiles = agiles
+	b.agileIndex = projectAgileIndex(agiles, project)
+	b.active = true
+	b.submitted = false
+	b.moveRequested = false
+	b.selectAgile(b.agileIndex)
+}
+
+// projectAgileIndex returns the index of the first board that includes the
+// project, or 0 when none does.
+func projectAgileIndex(agiles []model.Agile, project string) int {
+	if project == "" {
+		return 0
+	}
+	for i, agile := range agiles {
+		for _, p := range agile.Projects {
+			if strings.EqualFold(p.ShortName, project) {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+func (b *BoardView) Close() {
+	b.active = false
+}
+
+// Agile returns the board being shown, or nil if there is none.
+func (b *BoardView) Agile() *model.Agile {
+	if b.agileIndex < 0 || b.agileIndex >= len(b.agiles) {
+		return nil
+	}
+	return &b.agiles[b.agileIndex]
+}
+
+func (b *BoardView) selectAgile(i int) {
+	b.agileIndex = i
+	b.sprint = nil
+	b.col = 0
+	b.selected = ""
+	b.offset = 0
+	b.loading = b.Agile() != nil
+	b.sprintRequested = b.loading
+}
+
+// SetSprint shows the sprint's issues. The selected card stays selected if
+// it is still on the board, following it to its new column.
+func (b *BoardView) SetSprint(sprint *model.Sprint) {
+	b.loading = false
+	b.sprint = sprint
+	if agile := b.Agile(); agile != nil && b.selected != "" {
+		for i := range sprint.Issues {
+			if sprint.Issues[i].IDReadable == b.selected {
+				if col := agile.ColumnOf(&sprint.Issues[i]); col >= 0 {
+					b.col = col
+					return
+				}
+			}
+		}
+	}
+	b.selectIndex(0)
+}
+
+// SetError stops loading after a failed request.
+func (b *BoardView) SetError() {
+	b.loading = false
+}
+
+// lane is a swimlane: the issues sharing an assignee or parent.
+type lane struct {
+	title  string
+	issues []*model.Issue
+}
+
+// lanes groups the sprint's issues by the swimlane mode, in order of first
+// appearance, with issues lacking an assignee or parent last.
+func (b *BoardView) lanes() []lane {
+	if b.sprint == nil {
+		return nil
+	}
+	var lanes []lane
+	index := map[string]int{}
+	var rest lane
+	for i := range b.sprint.Issues {
+		issue := &b.sprint.Issues[i]
+		key, title := "", ""
+		switch b.swimlanes {
+		case swimlaneNone:
+			key = "all"
+		case swimlaneAssignee:
+			if u := issue.AssigneeValue(); u != nil {
+				key, title = u.Login, u.FullName
+				if title == "" {
+					title = u.Login
+				}
+			}
+			rest.title = "Unassigned"
+		case swimlaneParent:
+			key = issue.ParentID()
+			title = key
+			rest.title = "No parent"
+		}
+		if key == "" {
+			rest.issues = append(rest.issues, issue)
+			continue
+		}
+		if _, ok := index[key]; !ok {
+			index[key] = len(lanes)
+			lanes = append(lanes, lane{title: title})
+		}
+		lanes[index[key]].issues = append(lanes[index[key]].issues, issue)
+	}
+	if len(rest.issues) > 0 {
+		lanes = append(lanes, rest)
+	}
+	return lanes
+}
+
+// cards returns the issues in a column, lane by lane.
+func (b *BoardView) cards(col int) []*model.Issue {
+	agile := b.Agile()
+	if agile == nil {
+		return nil
+	}
+	var cards []*model.Issue
+	for _, l := range b.lanes() {
+		for _, issue := range l.issues {
+			if agile.ColumnOf(issue) == col {
+				cards = append(cards, issue)
+			}
+		}
+	}
+	return cards
+}
+
+// cursor returns the index of the selected card in the focused column.
+func (b *BoardView) cursor() int {
+	for i, issue := range b.cards(b.col) {
+		if issue.IDReadable == b.selected {
+			return i
+		}
+	}
+	return 0
+}
+
+func (b *BoardView) selectIndex(i int) {
+	cards := b.cards(b.col)
+	b.selected = ""
+	if len(cards) > 0 {
+		b.selected = cards[max(0, min(i, len(cards)-1))].IDReadable
+	}
+}
+
+// Selected returns the selected card, or nil.
+func (b *BoardView) Selected() *model.Issue {
+	for _, issue := range b.cards(b.col) {
+		if issue.IDReadable == b.selected {
+			return issue
+		}
+	}
+	return nil
+}
+
+func (b *BoardView) columnCount() int {
+	if agile := b.Agile(); agile != nil {
+		return len(agile.ColumnSettings.Columns)
+	}
+	return 0
+}
+
+func (b *BoardView) Update(msg tea.Msg) (BoardView, tea.Cmd) {
+	if !b.active {
+		return *b, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *b, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q":
+		b.Close()
+	case "left", "shift+tab":
+		if b.col > 0 {
+			pos := b.cursor()
+			b.col--
+			b.selectIndex(pos)
+		}
+	case "right", "tab":
+		if b.col < b.columnCount()-1 {
+			pos := b.cursor()
+			b.col++
+			b.selectIndex(pos)
+		}
+	case "up", "k":
+		b.selectIndex(b.cursor() - 1)
+	case "down", "j":
+		b.selectIndex(b.cursor() + 1)
+	case "h", "l":
+		issue := b.Selected()
+		target := b.col - 1
+		if keyMsg.String() == "l" {
+			target = b.col + 1
+		}
+		if issue != nil && !b.loading && target >= 0 && target < b.columnCount() {
+			b.moveRequested = true
+			b.moveIssue = issue
+			b.moveColumn = target
+			b.loading = true
+		}
+	case "s":
+		b.swimlanes = (b.swimlanes + 1) % swimlaneModeCount
+		b.offset = 0
+	case "[", "]":
+		if len(b.agiles) > 1 {
+			step := 1
+			if keyMsg.String() == "[" {
+				step = len(b.agiles) - 1
+			}
+			b.selectAgile((b.agileIndex + step) % len(b.agiles))
+		}
+	case "r":
+		if b.Agile() != nil {
+			b.loading = true
+			b.sprintRequested = true
+		}
+	case "enter":
+		if b.Selected() != nil {
+			b.submitted = true
+			b.Close()
+		}
+	}
+	return *b, nil
+}
+
+// View renders the board to fill width × height.
+func (b *BoardView) View(width, height int) string {
+	agile := b.Agile()
+	title := "Board"
+	if agile != nil {
+		title = "Board: " + agile.Name
+		if b.sprint != nil && b.sprint.Name != "" {
+			title += " — " + b.sprint.Name
+		}
+	}
+	header := titleStyle.Render(title)
+	if b.swimlanes != swimlaneNone {
+		header += hintDescStyle.Render("  swimlanes: " + b.swimlanes.String())
+	}
+
+	var body []string
+	selectedLine := 0
+	switch {
+	case agile == nil:
+		body = []string{"No agile boards found."}
+	case b.sprint == nil && b.loading:
+		body = []string{"Loading board..."}
+	case b.columnCount() == 0:
+		body = []string{"This board has no columns."}
+	default:
+		var colHeader string
+		colHeader, body, selectedLine = b.renderColumns(agile, width)
+		header += "\n\n" + colHeader
+	}
+
+	bodyHeight := max(height-lipgloss.Height(header)-1, 1)
+	if selectedLine < b.offset {
+		b.offset = selectedLine
+	}
+	if selectedLine >= b.offset+bodyHeight {
+		b.offset = selectedLine - bodyHeight + 1
+	}
+	b.offset = max(0, min(b.offset, max(len(body)-bodyHeight, 0)))
+	end := min(b.offset+bodyHeight, len(body))
+
+	return lipgloss.NewStyle().Width(width).Height(height).Render(header + "\n" + strings.Join(body[b.offset:end], "\n"))
+}
+
+// renderColumns renders the column headers and the card rows of the
+// columns that fit, scrolled to keep the focused column in view. Returns
+// the body line of the selected card too.
+func (b *BoardView) renderColumns(agile *model.Agile, width int) (string, []string, int) {
+	columns := agile.ColumnSettings.Columns
+	visible := max(1, min(len(columns), width/minBoardColumnWidth))
+	first := max(0, min(b.col-visible/2, len(columns)-visible))
+	colWidth := width / visible
+
+	cell := func(s string, style lipgloss.Style) string {
+		if lipgloss.Width(s) > colWidth-2 {
+			s = ansiTruncate(s, colWidth-3) + "…"
+		}
+		return style.Width(colWidth-1).Render(s) + " "
+	}
+	plain := lipgloss.NewStyle()
+	focusedHeader := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69"))
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("237")).Foreground(lipgloss.Color("255"))
+
+	var header strings.Builder
+	for c := first; c < first+visible; c++ {
+		style := plain.Bold(true)
+		if c == b.col {
+			style = focusedHeader
+		}
+		header.WriteString(cell(fmt.Sprintf("%s (%d)", columns[c].Title(), len(b.cards(c))), style))
+	}
+
+	var lines []string
+	selectedLine := 0
+	for _, l := range b.lanes() {
+		if b.swimlanes != swimlaneNone {
+			lines = append(lines, hintDescStyle.Render(fmt.Sprintf("▸ %s (%d)", l.title, len(l.issues))))
+		}
+		byColumn := make([][]*model.Issue, len(columns))
+		rows := 0
+		for _, issue := range l.issues {
+			if c := agile.ColumnOf(issue); c >= 0 {
+				byColumn[c] = append(byColumn[c], issue)
+				rows = max(rows, len(byColumn[c]))
+			}
+		}
+		for r := 0; r < rows; r++ {
+			var line strings.Builder
+			for c := first; c < first+visible; c++ {
+				if r >= len(byColumn[c]) {
+					line.WriteString(strings.Repeat(" ", colWidth))
+					continue
+				}
+				issue := byColumn[c][r]
+				style := plain
+				if c == b.col && issue.IDReadable == b.selected {
+					style = selectedStyle
+					selectedLine = len(lines)
+				}
+				line.WriteString(cell(issue.IDReadable+" "+issue.Summary, style))
+			}
+			lines = append(lines, strings.TrimRight(line.String(), " "))
+		}
+	}
+	if len(lines) == 0 {
+		lines = []string{hintDescStyle.Render("No issues in this sprint.")}
+	}
+	return header.String(), lines, selectedLine
+}
+
+// boardCmd carries out what the last board key asked for: loading a
+// sprint, moving a card or opening an issue.
+func (a *App) boardCmd() tea.Cmd {
+	b := &a.board
+	agile := b.Agile()
+	switch {
+	case b.submitted:
+		b.submitted = false
+		a.listCollapsed = true
+		a.focus = detailPane
+		a.resizePanels()
+		a.loading = true
+		return a.fetchDetailCmd(b.selected)
+	case agile == nil:
+		return nil
+	case b.moveRequested:
+		b.moveRequested = false
+		a.loading = true
+		return a.moveCardCmd(*agile, b.sprintID(), *b.moveIssue, b.moveColumn)
+	case b.sprintRequested:
+		b.sprintRequested = false
+		a.loading = true
+		return a.fetchSprintCmd(agile.ID, b.sprintID())
+	}
+	return nil
+}
+
+// sprintID returns the sprint being shown, or else the board's current
+// sprint.
+func (b *BoardView) sprintID() string {
+	if b.sprint != nil && b.sprint.ID != "" {
+		return b.sprint.ID
+	}
+	if agile := b.Agile(); agile != nil && agile.CurrentSprint != nil {
+		return agile.CurrentSprint.ID
+	}
+	return ""
+}
+
+func (a *App) fetchSprintCmd(agileID, sprintID string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		if sprintID == "" {
+			return errMsg{fmt.Errorf("the board has no current sprint")}
+		}
+		sprint, err := service.GetSprint(ctx, agileID, sprintID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return boardSprintLoadedMsg{agileID: agileID, sprint: sprint}
+	}
+}
+
+// moveCardCmd sets the board's column field of issue to the first value of
+// the target column, then reloads the sprint.
+func (a *App) moveCardCmd(agile model.Agile, sprintID string, issue model.Issue, column int) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	field := agile.ColumnField()
+	col := agile.ColumnSettings.Columns[column]
+	return func() tea.Msg {
+		if len(col.FieldValues) == 0 {
+			return errMsg{fmt.Errorf("column %s has no %s value", col.Title(), field)}
+		}
+		fieldType := "StateIssueCustomField"
+		for _, cf := range issue.CustomFields {
+			if cf.Name == field && cf.Type != "" {
+				fieldType = cf.Type
+			}
+		}
+		value := model.BundleField{Kind: model.FieldKind(fieldType), Values: col.FieldValues[:1]}
+		fields := map[string]any{
+			"customFields": []map[string]any{{
+				"name":  field,
+				"$type": fieldType,
+				"value": value.Payload(),
+			}},
+		}
+		if err := service.UpdateIssue(ctx, issue.IDReadable, fields); err != nil {
+			return errMsg{err}
+		}
+		sprint, err := service.GetSprint(ctx, agile.ID, sprintID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return boardSprintLoadedMsg{agileID: agile.ID, sprint: sprint}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func testAgile() model.Agile {
+	agile := model.Agile{ID: "108-1", Name: "Team board", CurrentSprint: &model.Sprint{ID: "109-4"}}
+	agile.Projects = []model.Project{{ID: "0-1", ShortName: "PROJ"}}
+	agile.ColumnSettings.Columns = []model.AgileColumn{
+		{Presentation: "Open", FieldValues: []model.BundleValue{{Name: "Open"}}},
+		{Presentation: "In Progress", FieldValues: []model.BundleValue{{Name: "In Progress"}}},
+		{Presentation: "Done", FieldValues: []model.BundleValue{{Name: "Fixed"}, {Name: "Verified"}}},
+	}
+	return agile
+}
+
+func boardIssue(id, state, assignee string) model.Issue {
+	issue := model.Issue{IDReadable: id, Summary: "Summary of " + id, CustomFields: []model.CustomField{
+		makeCustomField("State", "StateMachineIssueCustomField", state),
+	}}
+	if assignee != "" {
+		issue.CustomFields = append(issue.CustomFields, model.CustomField{
+			Name: "Assignee", Type: "SingleUserIssueCustomField",
+			Value: json.RawMessage(`{"login":"` + assignee + `","fullName":"` + strings.ToUpper(assignee) + `"}`),
+		})
+	}
+	return issue
+}
+
+// boardService serves one board and applies state changes to its sprint.
+type boardService struct {
+	mockService
+	issues  []model.Issue
+	updates []map[string]any
+}
+
+func (s *boardService) ListAgiles(ctx context.Context) ([]model.Agile, error) {
+	return []model.Agile{testAgile()}, nil
+}
+
+func (s *boardService) GetSprint(ctx context.Context, agileID, sprintID string) (*model.Sprint, error) {
+	return &model.Sprint{ID: sprintID, Name: "Sprint 4", Issues: append([]model.Issue(nil), s.issues...)}, nil
+}
+
+func (s *boardService) UpdateIssue(ctx context.Context, issueID string, fields map[string]any) error {
+	s.updates = append(s.updates, fields)
+	cf := fields["customFields"].([]map[string]any)[0]
+	name := cf["value"].(map[string]string)["name"]
+	for i := range s.issues {
+		if s.issues[i].IDReadable == issueID {
+			s.issues[i] = boardIssue(issueID, name, "")
+		}
+	}
+	return nil
+}
+
+func openTestBoard(t *testing.T, svc *boardService) *App {
+	t.Helper()
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if cmd == nil {
+		t.Fatal("expected boards to load")
+	}
+	_, cmd = app.Update(cmd())
+	if !app.board.active || cmd == nil {
+		t.Fatal("expected the board to open and load its sprint")
+	}
+	app.Update(cmd())
+	return app
+}
+
+func TestBoard_OpenShowsColumns(t *testing.T) {
+	svc := &boardService{issues: []model.Issue{
+		boardIssue("PROJ-1", "Open", "jane"),
+		boardIssue("PROJ-2", "In Progress", ""),
+		boardIssue("PROJ-3", "Verified", "bob"),
+	}}
+	app := openTestBoard(t, svc)
+
+	view := app.View()
+	for _, want := range []string{"Team board — Sprint 4", "Open (1)", "In Progress (1)", "Done (1)", "PROJ-3 Summary of PROJ-3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q on the board:\n%s", want, view)
+		}
+	}
+	if app.board.selected != "PROJ-1" {
+		t.Errorf("expected the first card selected, got %q", app.board.selected)
+	}
+}
+
+func TestBoard_OpenPicksFirstBoardOfProject(t *testing.T) {
+	agiles := []model.Agile{
+		{ID: "a-1", Name: "Other", Projects: []model.Project{{ShortName: "OTHER"}}},
+		{ID: "a-2", Name: "Team", Projects: []model.Project{{ShortName: "OTHER"}, {ShortName: "PROJ"}}},
+		{ID: "a-3", Name: "Ops", Projects: []model.Project{{ShortName: "PROJ"}}},
+	}
+	b := NewBoardView()
+	b.Open(agiles, "proj")
+	if agile := b.Agile(); agile == nil || agile.ID != "a-2" {
+		t.Errorf("expected the first board with PROJ, got %+v", agile)
+	}
+	b.Open(agiles, "NONE")
+	if agile := b.Agile(); agile == nil || agile.ID != "a-1" {
+		t.Errorf("expected the first board as fallback, got %+v", agile)
+	}
+}
+
+func TestBoard_MoveCard(t *testing.T) {
+	svc := &boardService{issues: []model.Issue{boardIssue("PROJ-1", "Open", "")}}
+	app := openTestBoard(t, svc)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if cmd == nil {
+		t.Fatal("expected a move command")
+	}
+	app.Update(cmd())
+
+	if len(svc.updates) != 1 {
+		t.Fatalf("expected one update, got %d", len(svc.updates))
+	}
+	cf := svc.updates[0]["customFields"].([]map[string]any)[0]
+	value := cf["value"].(map[string]string)
+	if cf["name"] != "State" || cf["$type"] != "StateMachineIssueCustomField" || value["name"] != "In Progress" || value["$type"] != "StateBundleElement" {
+		t.Errorf("unexpected state change: %v", cf)
+	}
+	if app.board.col != 1 || app.board.selected != "PROJ-1" {
+		t.Errorf("expected the selection to follow the card, got column %d %q", app.board.col, app.board.selected)
+	}
+
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}}); cmd != nil {
+		app.Update(cmd())
+	}
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}}); cmd != nil {
+		t.Error("expected no move past the last column")
+	}
+}
+
+func TestBoard_SwimlanesByAssignee(t *testing.T) {
+	svc := &boardService{issues: []model.Issue{
+		boardIssue("PROJ-1", "Open", ""),
+		boardIssue("PROJ-2", "Open", "jane"),
+		boardIssue("PROJ-3", "Fixed", "jane"),
+	}}
+	app := openTestBoard(t, svc)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+
+	var titles []string
+	for _, l := range app.board.lanes() {
+		titles = append(titles, l.title)
+	}
+	if strings.Join(titles, ",") != "JANE,Unassigned" {
+		t.Errorf("unexpected lanes %v", titles)
+	}
+	if cards := app.board.cards(0); len(cards) != 2 || cards[0].IDReadable != "PROJ-2" {
+		t.Errorf("expected cards ordered by lane, got %v", cards)
+	}
+	if !strings.Contains(app.View(), "▸ JANE (2)") {
+		t.Error("expected lane headers on the board")
+	}
+}
+
+func TestBoard_EnterOpensIssue(t *testing.T) {
+	svc := &boardService{issues: []model.Issue{boardIssue("PROJ-1", "Open", "")}}
+	app := openTestBoard(t, svc)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.board.active || cmd == nil {
+		t.Fatal("expected enter to close the board and load the issue")
+	}
+	if app.pendingDetailID != "PROJ-1" {
+		t.Errorf("expected PROJ-1 to load, got %q", app.pendingDetailID)
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// blockingService blocks GetIssue and ListIssues until their context is cancelled.
+type blockingService struct {
+	mockService
+}
+
+func (b *blockingService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (b *blockingService) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestFetchDetailCmd_SupersedesPrevious(t *testing.T) {
+	app := NewApp(&blockingService{}, config.DefaultState())
+
+	stale := app.fetchDetailCmd("PROJ-1")
+	_ = app.fetchDetailCmd("PROJ-2")
+
+	// The first fetch must have been cancelled by the second; otherwise this blocks.
+	msg := stale()
+	if em, ok := msg.(errMsg); !ok || em.err != context.Canceled {
+		t.Errorf("expected cancelled errMsg, got %#v", msg)
+	}
+	if app.pendingDetailID != "PROJ-2" {
+		t.Errorf("got pendingDetailID %q, want PROJ-2", app.pendingDetailID)
+	}
+}
+
+func TestFetchDetailCmd_DropsResponseAfterSupersede(t *testing.T) {
+	svc := &recordingService{}
+	app := NewApp(svc, config.DefaultState())
+
+	stale := app.fetchDetailCmd("PROJ-1")
+	_ = app.fetchDetailCmd("PROJ-2")
+
+	// recordingService ignores the context, so the stale fetch "succeeds";
+	// the command must still drop the result.
+	if msg := stale(); msg != nil {
+		t.Errorf("expected nil msg for superseded fetch, got %T", msg)
+	}
+}
+
+func TestFetchIssuesCmd_SupersedesPrevious(t *testing.T) {
+	app := NewApp(&blockingService{}, config.DefaultState())
+
+	stale := app.fetchIssuesCmd()
+	app.query = "#Unresolved"
+	_ = app.fetchIssuesCmd()
+
+	msg := stale()
+	if em, ok := msg.(errMsg); !ok || em.err != context.Canceled {
+		t.Errorf("expected cancelled errMsg, got %#v", msg)
+	}
+}
+
+func TestErrMsg_CancelledIsIgnored(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.loading = true
+
+	m, _ := app.Update(errMsg{context.Canceled})
+	a := m.(*App)
+
+	if a.err != "" {
+		t.Errorf("got err %q, want empty for cancelled request", a.err)
+	}
+	if !a.loading {
+		t.Error("cancelled request should not clear loading owned by its replacement")
+	}
+}
+
+func TestQuit_CancelsAppContext(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.statePath = t.TempDir() + "/state.yaml"
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	if app.ctx.Err() == nil {
+		t.Error("expected app context to be cancelled on quit")
+	}
+}
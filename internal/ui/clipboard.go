@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"github.com/atotto/clipboard"
+	"github.com/muesli/termenv"
+)
+
+// copyToClipboard puts text on the system clipboard. Without a clipboard
+// tool (e.g. over SSH) it falls back to the OSC 52 escape sequence, which
+// most terminals forward to the local clipboard.
+// A variable so tests can stub it out.
+var copyToClipboard = func(text string) {
+	if err := clipboard.WriteAll(text); err != nil {
+		termenv.Copy(text)
+	}
+}
@@ -1,22 +1,39 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/cf/lazytrack/internal/api"
 	"github.com/cf/lazytrack/internal/model"
 )
 
-// effectiveQuery returns the search query with project filter, filter bar clauses,
-// and user query combined.
+// effectiveQuery returns the search query with project filter, sprint filter,
+// filter bar clauses, and user query combined.
 func (a *App) effectiveQuery() string {
+	return a.queryWithFilters(a.query)
+}
+
+// queryWithFilters combines query with the active project, sprint and
+// toggle filters, as the issue list would run it.
+func (a *App) queryWithFilters(query string) string {
 	var parts []string
 
-	if a.activeProject != nil && !strings.Contains(strings.ToLower(a.query), "project:") {
+	if a.activeProject != nil && !strings.Contains(strings.ToLower(query), "project:") {
 		parts = append(parts, "project: "+a.activeProject.ShortName)
 	}
 
+	if a.activeSprint != nil && a.sprintBoard != nil {
+		parts = append(parts, sprintQuery(*a.sprintBoard, *a.activeSprint))
+	}
+
 	if a.filterMe {
 		parts = append(parts, "Assignee: me")
 	}
@@ -25,8 +42,8 @@ func (a *App) effectiveQuery() string {
 		parts = append(parts, tf)
 	}
 
-	if a.query != "" {
-		parts = append(parts, a.query)
+	if query != "" {
+		parts = append(parts, query)
 	}
 
 	return strings.Join(parts, " ")
@@ -48,12 +65,25 @@ func (a *App) resolveGotoProject() string {
 	return ""
 }
 
+// supersede cancels the in-flight request tracked by slot (if any) and returns
+// a fresh child of the app context whose cancel func replaces it.
+func (a *App) supersede(slot *context.CancelFunc) context.Context {
+	if *slot != nil {
+		(*slot)()
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	*slot = cancel
+	return ctx
+}
+
 // fetchIssuesCmd creates a command that fetches issues. Captures current query value.
+// Any list fetch still in flight is cancelled.
 func (a *App) fetchIssuesCmd() tea.Cmd {
 	query := a.effectiveQuery()
 	service := a.service
+	ctx := a.supersede(&a.listCancel)
 	return func() tea.Msg {
-		issues, err := service.ListIssues(query, 0, 50)
+		issues, err := service.ListIssues(ctx, query, 0, 50)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -67,8 +97,9 @@ func (a *App) fetchMoreIssuesCmd() tea.Cmd {
 	skip := len(a.issues)
 	pageSize := a.pageSize
 	service := a.service
+	ctx := a.supersede(&a.listCancel)
 	return func() tea.Msg {
-		issues, err := service.ListIssues(query, skip, pageSize)
+		issues, err := service.ListIssues(ctx, query, skip, pageSize)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -77,22 +108,81 @@ func (a *App) fetchMoreIssuesCmd() tea.Cmd {
 }
 
 // fetchDetailCmd creates a command that fetches issue detail. Captures issueID.
+// A detail fetch still in flight for another issue is cancelled, so a slow
+// stale response can never overwrite the newer selection.
+// While the work section is shown, work items are fetched alongside; failing
+// to load them (e.g. time tracking is disabled for the project) just leaves
+// the section empty.
 func (a *App) fetchDetailCmd(issueID string) tea.Cmd {
 	service := a.service
+	ctx := a.supersede(&a.detailCancel)
+	a.pendingDetailID = issueID
+	withWork := a.showWork
 	return func() tea.Msg {
-		issue, err := service.GetIssue(issueID)
+		workItems := make(chan []model.WorkItem, 1)
+		if withWork {
+			go func() {
+				items, err := service.ListWorkItems(ctx, issueID)
+				if err != nil {
+					items = nil
+				}
+				workItems <- items
+			}()
+		} else {
+			workItems <- nil
+		}
+
+		issue, err := service.GetIssue(ctx, issueID)
+		if api.IsNotFound(err) {
+			return errMsg{fmt.Errorf("%s not found", issueID)}
+		}
 		if err != nil {
 			return errMsg{err}
 		}
-		return issueDetailLoadedMsg{issue}
+		if ctx.Err() != nil {
+			return nil // superseded after the response arrived
+		}
+		return issueDetailLoadedMsg{issue: issue, workItems: <-workItems}
+	}
+}
+
+// fetchWorkItemsCmd loads the work items of an issue when the work section
+// is opened. Failures leave the section empty, as in fetchDetailCmd.
+func (a *App) fetchWorkItemsCmd(issueID string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		items, err := service.ListWorkItems(ctx, issueID)
+		if err != nil {
+			items = nil
+		}
+		return workItemsLoadedMsg{issueID: issueID, workItems: items}
+	}
+}
+
+// fetchProjectFieldsCmd loads a project's custom fields for the detail view.
+// A failure only costs the field order, so it is not reported. When the
+// admin endpoint is forbidden or missing the empty result is cached so it
+// isn't retried on every selection; other failures are retried next time.
+func (a *App) fetchProjectFieldsCmd(projectID string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		fields, err := service.ListProjectCustomFields(ctx, projectID)
+		if err != nil {
+			retry := !api.IsForbidden(err) && !api.IsNotFound(err)
+			return projectFieldsLoadedMsg{projectID: projectID, retry: retry}
+		}
+		return projectFieldsLoadedMsg{projectID: projectID, fields: fields}
 	}
 }
 
 // fetchCurrentUserCmd creates a command that fetches the current user.
 func (a *App) fetchCurrentUserCmd() tea.Cmd {
 	service := a.service
+	ctx := a.ctx
 	return func() tea.Msg {
-		user, err := service.GetCurrentUser()
+		user, err := service.GetCurrentUser(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -107,8 +197,9 @@ func (a *App) fetchMentionsCmd() tea.Cmd {
 		query = "project: " + a.activeProject.ShortName + " " + query
 	}
 	service := a.service
+	ctx := a.ctx
 	return func() tea.Msg {
-		issues, err := service.ListIssues(query, 0, 50)
+		issues, err := service.ListIssues(ctx, query, 0, 50)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -116,6 +207,194 @@ func (a *App) fetchMentionsCmd() tea.Cmd {
 	}
 }
 
+// linkSubtask makes childID a subtask of parentID. types are the cached link
+// types; they are fetched when nil.
+func linkSubtask(ctx context.Context, service IssueService, types []model.IssueLinkType, childID, parentID string) error {
+	if types == nil {
+		var err error
+		if types, err = service.ListLinkTypes(ctx); err != nil {
+			return err
+		}
+	}
+	linkID := subtaskLinkID(types)
+	if linkID == "" {
+		return errors.New("the server has no Subtask link type")
+	}
+	return service.AddIssueLink(ctx, childID, linkID, parentID)
+}
+
+// addCommentCmd posts a new comment on the issue.
+func (a *App) addCommentCmd(issueID, text string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		if _, err := service.AddComment(ctx, issueID, text); err != nil {
+			return errMsg{err}
+		}
+		return commentAddedMsg{}
+	}
+}
+
+// updateCommentCmd replaces the text of a comment.
+func (a *App) updateCommentCmd(issueID, commentID, text string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		if _, err := service.UpdateComment(ctx, issueID, commentID, text); err != nil {
+			return errMsg{err}
+		}
+		return commentUpdatedMsg{}
+	}
+}
+
+// deleteCommentCmd removes a comment from the issue.
+func (a *App) deleteCommentCmd(issueID, commentID string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		if err := service.DeleteComment(ctx, issueID, commentID); err != nil {
+			return errMsg{err}
+		}
+		return commentDeletedMsg{}
+	}
+}
+
+// fetchHistoryCmd loads the issue's activity feed. A history fetch still in
+// flight for a previous selection is cancelled. Other failures, except an
+// expired token, are shown in the history pane.
+func (a *App) fetchHistoryCmd(issueID string) tea.Cmd {
+	service := a.service
+	ctx := a.supersede(&a.historyCancel)
+	a.activities = nil
+	a.history.SetContent("Loading history...")
+	return func() tea.Msg {
+		activities, err := service.ListActivities(ctx, issueID)
+		if errors.Is(err, context.Canceled) || api.IsUnauthorized(err) {
+			return errMsg{err}
+		}
+		if err != nil {
+			return historyLoadedMsg{issueID: issueID, err: err}
+		}
+		return historyLoadedMsg{issueID: issueID, activities: activities}
+	}
+}
+
+// logWorkCmd creates a work item on the issue. fromTimer marks the result as
+// stopping the running timer.
+func (a *App) logWorkCmd(issueID string, item model.WorkItem, fromTimer bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		if _, err := service.CreateWorkItem(ctx, issueID, item); err != nil {
+			return errMsg{err}
+		}
+		return workLoggedMsg{issueID: issueID, minutes: item.Duration.Minutes, fromTimer: fromTimer}
+	}
+}
+
+// changeTagCmd adds tag to or removes it from the issue. current is the
+// issue's tags before the change, used to report the resulting set.
+func (a *App) changeTagCmd(issueID string, tag model.Tag, current []model.Tag, remove bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		var tags []model.Tag
+		var err error
+		if remove {
+			err = service.RemoveIssueTag(ctx, issueID, tag.ID)
+			for _, t := range current {
+				if t.ID != tag.ID {
+					tags = append(tags, t)
+				}
+			}
+		} else {
+			err = service.AddIssueTag(ctx, issueID, tag.ID)
+			tags = append(append(tags, current...), tag)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return tagsChangedMsg{issueID: issueID, tags: tags}
+	}
+}
+
+// changeLinkCmd adds targetID to (or, with remove set, removes it from) the
+// given link of issueID.
+func (a *App) changeLinkCmd(issueID, linkID, targetID string, remove bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		var err error
+		if remove {
+			err = service.RemoveIssueLink(ctx, issueID, linkID, targetID)
+		} else {
+			err = service.AddIssueLink(ctx, issueID, linkID, targetID)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return linksChangedMsg{}
+	}
+}
+
+// downloadAttachmentCmd saves an attachment to path, refusing to overwrite an
+// existing file. With open set, the file goes to a fresh temp file instead and
+// is handed to the desktop opener; the app removes it on quit.
+func (a *App) downloadAttachmentCmd(att model.Attachment, path string, open bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		var f *os.File
+		var err error
+		if open {
+			f, err = os.CreateTemp("", "lazytrack-"+att.ID+"-*-"+filepath.Base(att.Name))
+		} else {
+			f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		}
+		if errors.Is(err, fs.ErrExist) {
+			return errMsg{fmt.Errorf("saving %s: %s already exists", att.Name, path)}
+		}
+		if err != nil {
+			return errMsg{fmt.Errorf("saving %s: %w", att.Name, err)}
+		}
+		path = f.Name()
+		err = service.DownloadAttachment(ctx, att, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path)
+			return errMsg{err}
+		}
+		if !open {
+			return attachmentSavedMsg{path}
+		}
+		if err := startOpener(path); err != nil {
+			os.Remove(path)
+			return errMsg{fmt.Errorf("opening %s: %w", att.Name, err)}
+		}
+		return attachmentOpenedMsg{path}
+	}
+}
+
+// uploadAttachmentCmd attaches the file at path to the issue.
+func (a *App) uploadAttachmentCmd(issueID, path string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		f, err := os.Open(path)
+		if err != nil {
+			return errMsg{fmt.Errorf("opening %s: %w", path, err)}
+		}
+		defer f.Close()
+		name := filepath.Base(path)
+		if _, err := service.UploadAttachment(ctx, issueID, name, f); err != nil {
+			return errMsg{err}
+		}
+		return attachmentUploadedMsg{name}
+	}
+}
+
 // buildTypeFilter returns a YouTrack Type filter clause.
 // Returns "Type: Bug" or "Type: Task" when one is set,
 // "Type: Bug,Task" when both are set, or "" when neither is set.
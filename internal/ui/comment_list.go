@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// CommentList is the comments pane: the selected issue's comments, newest
+// first, with a cursor on one of them. j/k move between comments; other
+// keys scroll the underlying viewport so long comments stay readable.
+type CommentList struct {
+	viewport viewport.Model
+	comments []model.Comment // display order, newest first
+	bodies   []string        // markdown of each comment rendered at the viewport width
+	cursor   int
+	offsets  []int // first line of each comment in the rendered content
+}
+
+func NewCommentList() CommentList {
+	return CommentList{viewport: viewport.New(0, 0)}
+}
+
+// SetComments replaces the comments, given in server order (oldest first).
+// With reset the cursor returns to the newest comment and the view to the
+// top; otherwise the cursor stays put, e.g. after editing a comment.
+func (l *CommentList) SetComments(comments []model.Comment, reset bool) {
+	l.comments = make([]model.Comment, len(comments))
+	for i, c := range comments {
+		l.comments[len(comments)-1-i] = c
+	}
+	if reset {
+		l.cursor = 0
+		l.viewport.GotoTop()
+	}
+	l.clampCursor()
+	l.bodies = renderCommentBodies(l.comments, l.viewport.Width)
+	l.render()
+}
+
+// SetSize sets the pane's content width and height. The comments are only
+// re-rendered when the width changes, since markdown rendering is costly.
+func (l *CommentList) SetSize(width, height int) {
+	l.viewport.Height = height
+	if width == l.viewport.Width {
+		return
+	}
+	l.viewport.Width = width
+	l.bodies = renderCommentBodies(l.comments, width)
+	l.render()
+}
+
+// Len returns the number of comments.
+func (l *CommentList) Len() int {
+	return len(l.comments)
+}
+
+// Selected returns the comment under the cursor, or nil if there are none.
+func (l *CommentList) Selected() *model.Comment {
+	if l.cursor >= len(l.comments) {
+		return nil
+	}
+	c := l.comments[l.cursor]
+	return &c
+}
+
+// Move selects the next (delta > 0) or previous comment and scrolls it into
+// view. Only the selection frame is redrawn; the bodies come from the cache.
+func (l *CommentList) Move(delta int) {
+	if len(l.comments) == 0 {
+		return
+	}
+	l.cursor += delta
+	l.clampCursor()
+	l.render()
+
+	offset := l.offsets[l.cursor]
+	if offset < l.viewport.YOffset || offset >= l.viewport.YOffset+l.viewport.Height {
+		l.viewport.SetYOffset(offset)
+	}
+}
+
+func (l *CommentList) clampCursor() {
+	if l.cursor >= len(l.comments) {
+		l.cursor = len(l.comments) - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+}
+
+// render lays out the cached comment bodies with the selection frame.
+func (l *CommentList) render() {
+	content, offsets := layoutCommentList(l.comments, l.bodies, l.cursor, time.Now())
+	l.viewport.SetContent(content)
+	l.offsets = offsets
+}
+
+func (l CommentList) Update(msg tea.Msg) (CommentList, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "j", "down":
+			l.Move(1)
+			return l, nil
+		case "k", "up":
+			l.Move(-1)
+			return l, nil
+		}
+	}
+	var cmd tea.Cmd
+	l.viewport, cmd = l.viewport.Update(msg)
+	return l, cmd
+}
+
+func (l CommentList) View() string {
+	return l.viewport.View()
+}
+
+// renderCommentList renders comments (already newest first) as list items:
+// a header with author, relative time and an "edited" marker, then the
+// markdown body. The selected item carries a bar down its left edge.
+// It also returns the line each item starts on.
+func renderCommentList(comments []model.Comment, width, selected int, now time.Time) (string, []int) {
+	return layoutCommentList(comments, renderCommentBodies(comments, width), selected, now)
+}
+
+// renderCommentBodies renders the markdown of each comment to fit a list
+// item in a pane of the given width.
+func renderCommentBodies(comments []model.Comment, width int) []string {
+	bodyWidth := width - 2
+	if bodyWidth < 1 {
+		bodyWidth = 1
+	}
+	bodies := make([]string, len(comments))
+	for i, c := range comments {
+		bodies[i] = strings.TrimRight(renderMarkdown(c.Text, bodyWidth), "\n")
+	}
+	return bodies
+}
+
+// layoutCommentList frames already rendered comment bodies as list items;
+// see renderCommentList.
+func layoutCommentList(comments []model.Comment, bodies []string, selected int, now time.Time) (string, []int) {
+	selectedStyle := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color("69")).
+		PaddingLeft(1)
+	normalStyle := lipgloss.NewStyle().
+		Border(lipgloss.HiddenBorder(), false, false, false, true).
+		PaddingLeft(1)
+
+	var b strings.Builder
+	offsets := make([]int, 0, len(comments))
+	lines := 0
+
+	for i, c := range comments {
+		offsets = append(offsets, lines)
+
+		meta := []string{}
+		if c.Created > 0 {
+			meta = append(meta, relativeTime(c.Created, now))
+		}
+		if c.Updated != nil && *c.Updated > c.Created {
+			meta = append(meta, "edited")
+		}
+		header := fmt.Sprintf("%s %s", iconComment, commentAuthor(&c))
+		if len(meta) > 0 {
+			header += hintDescStyle.Render(" · " + strings.Join(meta, " · "))
+		}
+
+		style := normalStyle
+		if i == selected {
+			style = selectedStyle
+		}
+		entry := style.Render(header+"\n"+bodies[i]) + "\n"
+		if i < len(comments)-1 {
+			entry += "\n"
+		}
+		b.WriteString(entry)
+		lines += strings.Count(entry, "\n")
+	}
+
+	return b.String(), offsets
+}
+
+// relativeTime formats a millisecond timestamp relative to now, e.g.
+// "5m ago" or "3d ago". Anything older than a month shows the date.
+func relativeTime(ms int64, now time.Time) string {
+	t := time.UnixMilli(ms)
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
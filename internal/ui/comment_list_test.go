@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{20 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{50 * time.Hour, "2d ago"},
+		{45 * 24 * time.Hour, now.Add(-45 * 24 * time.Hour).Local().Format("2006-01-02")},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(-tt.ago).UnixMilli(), now); got != tt.want {
+			t.Errorf("relativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestRenderCommentList(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-2 * time.Hour).UnixMilli()
+	edited := now.Add(-time.Hour).UnixMilli()
+	comments := []model.Comment{
+		{ID: "c2", Text: "two", Author: &model.User{FullName: "Bob"}, Created: created, Updated: &edited},
+		{ID: "c1", Text: "one", Author: &model.User{Login: "ann"}, Created: created},
+	}
+
+	content, offsets := renderCommentList(comments, 40, 0, now)
+
+	if len(offsets) != 2 || offsets[0] != 0 {
+		t.Fatalf("unexpected offsets: %v", offsets)
+	}
+	lines := strings.Split(content, "\n")
+	if !strings.Contains(lines[0], "Bob") || !strings.Contains(lines[0], "2h ago") || !strings.Contains(lines[0], "edited") {
+		t.Errorf("unexpected first header: %q", lines[0])
+	}
+	if !strings.Contains(lines[offsets[1]], "ann") || strings.Contains(lines[offsets[1]], "edited") {
+		t.Errorf("unexpected second header: %q", lines[offsets[1]])
+	}
+	if !strings.Contains(lines[0], "┃") || strings.Contains(lines[offsets[1]], "┃") {
+		t.Error("expected only the selected comment to carry the cursor bar")
+	}
+}
+
+func TestCommentList_Navigation(t *testing.T) {
+	l := NewCommentList()
+	l.SetSize(40, 3)
+	l.SetComments([]model.Comment{{ID: "c1", Text: "one"}, {ID: "c2", Text: "two"}, {ID: "c3", Text: "three"}}, true)
+
+	if c := l.Selected(); c == nil || c.ID != "c3" {
+		t.Fatalf("expected newest comment first, got %+v", c)
+	}
+	l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if c := l.Selected(); c.ID != "c1" {
+		t.Errorf("expected cursor clamped at oldest comment, got %s", c.ID)
+	}
+	if l.viewport.YOffset == 0 {
+		t.Error("expected viewport to scroll the selected comment into view")
+	}
+
+	// Reloading the same issue keeps the cursor; a fresh issue resets it
+	l.SetComments([]model.Comment{{ID: "c1"}, {ID: "c2"}, {ID: "c3"}}, false)
+	if c := l.Selected(); c.ID != "c1" {
+		t.Errorf("expected cursor kept on reload, got %s", c.ID)
+	}
+	l.SetComments([]model.Comment{{ID: "c9"}}, true)
+	if c := l.Selected(); c.ID != "c9" {
+		t.Errorf("expected cursor reset, got %s", c.ID)
+	}
+}
+
+func TestCommentList_SetSizeRendersOnWidthChange(t *testing.T) {
+	l := NewCommentList()
+	l.SetSize(40, 10)
+	l.SetComments([]model.Comment{{ID: "c1", Text: "before"}}, true)
+
+	// Changing the stored comment behind the list's back shows whether
+	// SetSize rendered again.
+	l.comments[0].Text = "after"
+	l.SetSize(40, 12)
+	if !strings.Contains(l.View(), "before") {
+		t.Error("expected no re-render when only the height changes")
+	}
+	l.SetSize(50, 12)
+	if !strings.Contains(l.View(), "after") {
+		t.Error("expected a re-render when the width changes")
+	}
+}
+
+func TestCommentList_MoveReusesRenderedBodies(t *testing.T) {
+	l := NewCommentList()
+	l.SetSize(40, 20)
+	l.SetComments([]model.Comment{{ID: "c1", Text: "one"}, {ID: "c2", Text: "two"}}, true)
+
+	l.comments[1].Text = "changed"
+	l.Move(1)
+	if c := l.Selected(); c.ID != "c1" {
+		t.Fatalf("expected cursor on c1, got %s", c.ID)
+	}
+	view := l.View()
+	if strings.Contains(view, "changed") || !strings.Contains(view, "one") {
+		t.Errorf("expected Move to reuse the rendered bodies:\n%s", view)
+	}
+	if len(l.offsets) != 2 || l.offsets[1] == 0 {
+		t.Errorf("expected offsets recomputed, got %v", l.offsets)
+	}
+}
+
+func TestComments_CopyAndOpen(t *testing.T) {
+	var copied, opened string
+	origCopy, origOpen := copyToClipboard, startOpener
+	copyToClipboard = func(text string) { copied = text }
+	startOpener = func(target string) error { opened = target; return nil }
+	defer func() { copyToClipboard, startOpener = origCopy, origOpen }()
+
+	app := newCommentsTestApp(&commentService{})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if copied != "thrid" {
+		t.Errorf("got copied %q, want comment text", copied)
+	}
+	if app.notice != "Copied comment by Cat" {
+		t.Errorf("got notice %q", app.notice)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if opened != "https://yt.example.com/issue/PROJ-1#c3" {
+		t.Errorf("got opened %q", opened)
+	}
+}
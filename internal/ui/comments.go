@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// openCommentEditor opens text in $EDITOR. On save the result replaces the
+// text of comment, or is posted as a new comment when comment is nil. New
+// comments get a header with the issue's recent discussion for context.
+func (a *App) openCommentEditor(comment *model.Comment, text string) tea.Cmd {
+	issueID := a.selected.IDReadable
+	content := text
+	if comment == nil {
+		content = commentHeader(a.selected, time.Now()) + text
+	}
+	tempPath, err := writeCommentTempFile(issueID, content)
+	if err != nil {
+		a.err = "Failed to create temp file: " + err.Error()
+		return nil
+	}
+	c := exec.Command(resolveEditor(), tempPath)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return commentEditorFinishedMsg{err: err, tempPath: tempPath, issueID: issueID, comment: comment, initial: text}
+	})
+}
+
+// quoteReply starts a reply to c: its text as a markdown blockquote,
+// followed by an @mention of its author.
+func quoteReply(c *model.Comment) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(c.Text), "\n") {
+		if line == "" {
+			b.WriteString(">\n")
+		} else {
+			b.WriteString("> " + line + "\n")
+		}
+	}
+	b.WriteString("\n")
+	if c.Author != nil && c.Author.Login != "" {
+		b.WriteString("@" + c.Author.Login + " ")
+	}
+	return b.String()
+}
+
+// commentPanelContent renders the comment textarea with any @mention
+// suggestions below it.
+func (a *App) commentPanelContent(width int) string {
+	content := a.commentInput.View() + "\n\n"
+	if suggestions := a.mentions.View(width); suggestions != "" {
+		content += suggestions + "\n"
+	}
+	return content + hintDescStyle.Render("ctrl+s: submit  esc: cancel")
+}
+
+// commentPanelTitle is the title of the comment textarea panel.
+func (a *App) commentPanelTitle() string {
+	if a.editingComment != nil {
+		return iconFile + " Edit Comment"
+	}
+	return iconFile + " Add Comment"
+}
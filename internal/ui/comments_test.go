@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// commentService records comment edits and deletions.
+type commentService struct {
+	mockService
+	updated []string
+	deleted []string
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, issueID, commentID, text string) (*model.Comment, error) {
+	s.updated = append(s.updated, issueID+" "+commentID+" "+text)
+	return &model.Comment{ID: commentID, Text: text}, nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, issueID, commentID string) error {
+	s.deleted = append(s.deleted, issueID+" "+commentID)
+	return nil
+}
+
+func newCommentsTestApp(svc IssueService) *App {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 160
+	app.height = 40
+	app.Update(issueDetailLoadedMsg{issue: &model.Issue{
+		IDReadable: "PROJ-1",
+		Comments: []model.Comment{
+			{ID: "c1", Text: "first", Author: &model.User{Login: "ann"}},
+			{ID: "c2", Text: "second", Author: &model.User{Login: "bob"}},
+			{ID: "c3", Text: "thrid", Author: &model.User{Login: "cat", FullName: "Cat"}},
+		},
+	}})
+	app.focus = commentsPane
+	return app
+}
+
+func TestComments_SelectAndEditInPlace(t *testing.T) {
+	svc := &commentService{}
+	app := newCommentsTestApp(svc)
+
+	if c := app.comments.Selected(); c == nil || c.ID != "c3" {
+		t.Fatalf("expected newest comment selected, got %+v", c)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !app.commenting || app.commentInput.Value() != "thrid" {
+		t.Fatalf("expected textarea prefilled with comment, got %q", app.commentInput.Value())
+	}
+	if !strings.Contains(app.View(), "Edit Comment") {
+		t.Error("expected edit comment panel title")
+	}
+
+	app.commentInput.SetValue("third")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("expected update comment cmd")
+	}
+	if _, ok := cmd().(commentUpdatedMsg); !ok {
+		t.Fatal("expected commentUpdatedMsg")
+	}
+	if len(svc.updated) != 1 || svc.updated[0] != "PROJ-1 c3 third" {
+		t.Errorf("unexpected updates: %v", svc.updated)
+	}
+	if app.editingComment != nil {
+		t.Error("expected edit state cleared after submit")
+	}
+}
+
+func TestComments_DeleteNeedsConfirmation(t *testing.T) {
+	svc := &commentService{}
+	app := newCommentsTestApp(svc)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !strings.Contains(app.View(), "Delete comment by bob?") {
+		t.Error("expected delete confirmation naming the author")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if app.confirmCommentDelete {
+		t.Fatal("expected n to cancel")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected delete comment cmd")
+	}
+	cmd()
+	if len(svc.deleted) != 1 || svc.deleted[0] != "PROJ-1 c2" {
+		t.Errorf("unexpected deletions: %v", svc.deleted)
+	}
+}
+
+func TestComments_EditorResult(t *testing.T) {
+	svc := &commentService{}
+	app := newCommentsTestApp(svc)
+	comment := app.comments.Selected()
+
+	path, err := writeCommentTempFile("PROJ-1", comment.Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1", comment: comment, initial: comment.Text}); cmd != nil {
+		t.Error("expected unchanged comment to be left alone")
+	}
+
+	path, _ = writeCommentTempFile("PROJ-1", "third\n\n")
+	_, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1", comment: comment, initial: comment.Text})
+	if cmd == nil {
+		t.Fatal("expected update comment cmd")
+	}
+	cmd()
+	if len(svc.updated) != 1 || svc.updated[0] != "PROJ-1 c3 third" {
+		t.Errorf("unexpected updates: %v", svc.updated)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected temp file to be removed")
+	}
+}
+
+// addService records new comments.
+type addService struct {
+	mockService
+	added []string
+}
+
+func (s *addService) AddComment(ctx context.Context, issueID, text string) (*model.Comment, error) {
+	s.added = append(s.added, issueID+" "+text)
+	return &model.Comment{ID: "c4", Text: text}, nil
+}
+
+func TestQuoteReply(t *testing.T) {
+	c := &model.Comment{Text: "line one\n\nline two\n", Author: &model.User{Login: "ann"}}
+	want := "> line one\n>\n> line two\n\n@ann "
+	if got := quoteReply(c); got != want {
+		t.Errorf("quoteReply() = %q, want %q", got, want)
+	}
+	if got := quoteReply(&model.Comment{Text: "hi"}); got != "> hi\n\n" {
+		t.Errorf("quoteReply() without author = %q", got)
+	}
+}
+
+func TestComments_QuoteReplyInTextarea(t *testing.T) {
+	svc := &addService{}
+	app := newCommentsTestApp(svc)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if !app.commenting || app.editingComment != nil {
+		t.Fatal("expected a new comment to be started")
+	}
+	if got := app.commentInput.Value(); got != "> second\n\n@bob " {
+		t.Fatalf("unexpected reply prefill %q", got)
+	}
+
+	app.commentInput.InsertString("agreed")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("expected add comment cmd")
+	}
+	if _, ok := cmd().(commentAddedMsg); !ok {
+		t.Fatal("expected commentAddedMsg")
+	}
+	if len(svc.added) != 1 || svc.added[0] != "PROJ-1 > second\n\n@bob agreed" {
+		t.Errorf("unexpected comments: %q", svc.added)
+	}
+}
+
+func TestComments_QuoteReplyEditorResult(t *testing.T) {
+	svc := &addService{}
+	app := newCommentsTestApp(svc)
+	initial := quoteReply(app.comments.Selected())
+
+	path, _ := writeCommentTempFile("PROJ-1", initial)
+	if _, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1", initial: initial}); cmd != nil {
+		t.Error("expected untouched reply to be discarded")
+	}
+
+	path, _ = writeCommentTempFile("PROJ-1", initial+"thanks\n")
+	_, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1", initial: initial})
+	if cmd == nil {
+		t.Fatal("expected add comment cmd")
+	}
+	cmd()
+	if len(svc.added) != 1 || svc.added[0] != "PROJ-1 > thrid\n\n@cat thanks" {
+		t.Errorf("unexpected comments: %q", svc.added)
+	}
+}
+
+func TestComments_NewCommentInEditor(t *testing.T) {
+	svc := &addService{}
+	app := newCommentsTestApp(svc)
+	header := commentHeader(app.selected, time.Now())
+
+	path, _ := writeCommentTempFile("PROJ-1", header)
+	if _, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1"}); cmd != nil {
+		t.Error("expected a comment left empty to be discarded")
+	}
+
+	path, _ = writeCommentTempFile("PROJ-1", header+"Long comment\n\n```go\nfmt.Println()\n```\n")
+	_, cmd := app.Update(commentEditorFinishedMsg{tempPath: path, issueID: "PROJ-1"})
+	if cmd == nil {
+		t.Fatal("expected add comment cmd")
+	}
+	cmd()
+	if len(svc.added) != 1 || svc.added[0] != "PROJ-1 Long comment\n\n```go\nfmt.Println()\n```" {
+		t.Errorf("unexpected comments: %q", svc.added)
+	}
+}
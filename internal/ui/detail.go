@@ -5,10 +5,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/cf/lazytrack/internal/model"
 )
 
-func renderIssueDetail(issue *model.Issue, width int) string {
+func renderIssueDetail(issue *model.Issue, workItems []model.WorkItem, fields fieldsLayout, width int) string {
 	var b strings.Builder
 
 	b.WriteString(titleStyle.Render(issue.IDReadable+" "+issue.Summary) + "\n\n")
@@ -17,25 +19,27 @@ func renderIssueDetail(issue *model.Issue, width int) string {
 		fmt.Fprintf(&b, "Project: %s (%s)\n", issue.Project.Name, issue.Project.ShortName)
 	}
 
-	state := issue.StateValue()
-	if state != "" {
-		fmt.Fprintf(&b, "State: %s\n", stateColor(state))
-	}
-
-	if assignee := issue.AssigneeValue(); assignee != nil {
-		fmt.Fprintf(&b, "Assignee: %s\n", assignee.FullName)
+	if block := renderCustomFields(issue, fields, width); block != "" {
+		b.WriteString(block + "\n")
 	}
 
 	if issue.Reporter != nil {
 		fmt.Fprintf(&b, "Reporter: %s\n", issue.Reporter.FullName)
 	}
 
+	if len(issue.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", renderTagChips(issue.Tags))
+	}
+
 	if issue.Created > 0 {
 		fmt.Fprintf(&b, "Created: %s\n", formatTimestamp(issue.Created))
 	}
 	if issue.Updated > 0 {
 		fmt.Fprintf(&b, "Updated: %s\n", formatTimestamp(issue.Updated))
 	}
+	if summary := renderTimeSummary(issue, workItems); summary != "" {
+		fmt.Fprintf(&b, "Time: %s\n", summary)
+	}
 
 	b.WriteString("\n────────────────────────────────\n\n")
 
@@ -45,28 +49,234 @@ func renderIssueDetail(issue *model.Issue, width int) string {
 		b.WriteString("(no description)\n")
 	}
 
+	if rows := flattenLinks(issue.Links); len(rows) > 0 {
+		b.WriteString("\n" + renderLinks(rows))
+	}
+
+	if len(issue.Attachments) > 0 {
+		b.WriteString("\n" + renderAttachments(issue.Attachments))
+	}
+
+	if len(workItems) > 0 {
+		b.WriteString("\n" + renderWorkItems(workItems))
+	}
+
 	return b.String()
 }
 
-func renderComments(comments []model.Comment, width int) string {
+func renderLinks(rows []linkRow) string {
 	var b strings.Builder
 
-	for i := len(comments) - 1; i >= 0; i-- {
-		c := comments[i]
-		author := "Unknown"
-		if c.Author != nil {
-			if c.Author.FullName != "" {
-				author = c.Author.FullName
-			} else if c.Author.Login != "" {
-				author = c.Author.Login
+	fmt.Fprintf(&b, "%s Links (%d)\n", iconLink, len(rows))
+	for _, row := range rows {
+		id := row.issue.IDReadable
+		if row.issue.Resolved != nil {
+			id = hintDescStyle.Strikethrough(true).Render(id)
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", hintDescStyle.Render(row.phrase), id, row.issue.Summary)
+	}
+	b.WriteString(hintDescStyle.Render("  space l: jump/add/remove") + "\n")
+
+	return b.String()
+}
+
+// fieldsLayout controls the custom fields block of the detail view.
+type fieldsLayout struct {
+	order     []model.ProjectCustomField // the project's fields, in its order
+	hideEmpty bool
+}
+
+// fieldEntry is one custom field as shown in the detail view.
+type fieldEntry struct {
+	name  string
+	value string // rendered; "" when empty
+}
+
+// detailFields returns the issue's custom fields for display: those the
+// project defines first, in its order, then any others as the issue lists
+// them. Fields the issue lacks count as empty.
+func detailFields(issue *model.Issue, fields fieldsLayout) []fieldEntry {
+	byName := map[string]model.CustomField{}
+	for _, cf := range issue.CustomFields {
+		byName[cf.Name] = cf
+	}
+	var names []string
+	seen := map[string]bool{}
+	for _, pf := range fields.order {
+		if !seen[pf.Field.Name] {
+			seen[pf.Field.Name] = true
+			names = append(names, pf.Field.Name)
+		}
+	}
+	for _, cf := range issue.CustomFields {
+		if !seen[cf.Name] {
+			seen[cf.Name] = true
+			names = append(names, cf.Name)
+		}
+	}
+
+	var entries []fieldEntry
+	for _, name := range names {
+		value := ""
+		if cf, ok := byName[name]; ok {
+			if v, err := cf.Decode(); err == nil {
+				value = v.Display()
+			}
+		}
+		if first, _, multiline := strings.Cut(value, "\n"); multiline {
+			value = first + " …"
+		}
+		if value == "" && fields.hideEmpty {
+			continue
+		}
+		if name == "State" && value != "" {
+			value = stateColor(value)
+		}
+		entries = append(entries, fieldEntry{name: name, value: value})
+	}
+	return entries
+}
+
+// renderCustomFields lays out the issue's custom fields as "Name: value"
+// pairs in two columns, or one when the pane is narrow. Empty fields show
+// a dash.
+func renderCustomFields(issue *model.Issue, fields fieldsLayout, width int) string {
+	entries := detailFields(issue, fields)
+	if len(entries) == 0 {
+		return ""
+	}
+
+	labelWidth := 0
+	for _, e := range entries {
+		labelWidth = max(labelWidth, lipgloss.Width(e.name)+1)
+	}
+	columns := 2
+	if width < 60 {
+		columns = 1
+	}
+	colWidth := width / columns
+	valueWidth := max(colWidth-labelWidth-3, 4)
+
+	cell := func(e fieldEntry) string {
+		label := hintDescStyle.Render(fmt.Sprintf("%-*s", labelWidth, e.name+":"))
+		value := e.value
+		if value == "" {
+			value = hintDescStyle.Render("—")
+		}
+		if lipgloss.Width(value) > valueWidth {
+			value = ansiTruncate(value, valueWidth-1) + "…"
+		}
+		return label + " " + value
+	}
+
+	rows := (len(entries) + columns - 1) / columns
+	var lines []string
+	for r := 0; r < rows; r++ {
+		line := cell(entries[r])
+		if columns == 2 && r+rows < len(entries) {
+			line += strings.Repeat(" ", max(colWidth-lipgloss.Width(line), 1)) + cell(entries[r+rows])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTimeSummary returns logged time against the estimation, e.g.
+// "3h 30m logged / 5h estimated (70%)", or "" if there is neither. Without
+// loaded work items the logged time comes from the "Spent time" field.
+func renderTimeSummary(issue *model.Issue, workItems []model.WorkItem) string {
+	spent := model.TotalMinutes(workItems)
+	if workItems == nil {
+		spent, _ = issue.PeriodValue("Spent time")
+	}
+	estimate, hasEstimate := issue.PeriodValue("Estimation")
+	hasEstimate = hasEstimate && estimate > 0
+	switch {
+	case hasEstimate:
+		s := fmt.Sprintf("%s logged / %s estimated (%d%%)", model.FormatMinutes(spent), model.FormatMinutes(estimate), spent*100/estimate)
+		if spent > estimate {
+			return errorStyle.UnsetPadding().Render(s)
+		}
+		return s
+	case spent > 0:
+		return model.FormatMinutes(spent) + " logged"
+	default:
+		return ""
+	}
+}
+
+// maxWorkItemsShown caps the work section; the total above covers the rest.
+const maxWorkItemsShown = 10
+
+func renderWorkItems(items []model.WorkItem) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s Work (%d)\n", iconWork, len(items))
+	for i := len(items) - 1; i >= 0 && i >= len(items)-maxWorkItemsShown; i-- {
+		item := items[i]
+		meta := []string{time.UnixMilli(item.Date).Format("2006-01-02")}
+		if item.Type != nil && item.Type.Name != "" {
+			meta = append(meta, item.Type.Name)
+		}
+		if item.Author != nil {
+			name := item.Author.FullName
+			if name == "" {
+				name = item.Author.Login
 			}
+			meta = append(meta, name)
+		}
+		line := fmt.Sprintf("  %-7s %s", model.FormatMinutes(item.Duration.Minutes), hintDescStyle.Render(strings.Join(meta, " · ")))
+		if item.Text != "" {
+			line += "  " + item.Text
 		}
-		ts := ""
-		if c.Created > 0 {
-			ts = " (" + formatTimestamp(c.Created) + ")"
+		b.WriteString(line + "\n")
+	}
+	if hidden := len(items) - maxWorkItemsShown; hidden > 0 {
+		b.WriteString(hintDescStyle.Render(fmt.Sprintf("  … %d earlier", hidden)) + "\n")
+	}
+	b.WriteString(hintDescStyle.Render("  space w: log work") + "\n")
+
+	return b.String()
+}
+
+func renderAttachments(attachments []model.Attachment) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s Attachments (%d)\n", iconAttachment, len(attachments))
+	for _, att := range attachments {
+		meta := []string{formatSize(att.Size)}
+		if att.Author != nil && att.Author.FullName != "" {
+			meta = append(meta, att.Author.FullName)
 		}
-		fmt.Fprintf(&b, "%s %s%s\n%s\n", iconComment, author, ts, renderMarkdown(c.Text, width))
-		if i > 0 {
+		if att.Created > 0 {
+			meta = append(meta, formatTimestamp(att.Created))
+		}
+		fmt.Fprintf(&b, "  %s  %s\n", att.Name, hintDescStyle.Render(strings.Join(meta, " · ")))
+	}
+	b.WriteString(hintDescStyle.Render("  space o: open/save  space u: upload") + "\n")
+
+	return b.String()
+}
+
+func renderHistory(activities []model.Activity, width int) string {
+	if len(activities) == 0 {
+		return hintDescStyle.Render("No history")
+	}
+
+	var b strings.Builder
+
+	for i, act := range activities {
+		author := "Unknown"
+		if act.Author != nil {
+			if act.Author.FullName != "" {
+				author = act.Author.FullName
+			} else if act.Author.Login != "" {
+				author = act.Author.Login
+			}
+		}
+		fmt.Fprintf(&b, "%s %s\n", author, hintDescStyle.Render(formatTimestamp(act.Timestamp)))
+		b.WriteString(lipgloss.NewStyle().Width(width).PaddingLeft(2).Render(act.Describe()) + "\n")
+		if i < len(activities)-1 {
 			b.WriteString("\n")
 		}
 	}
@@ -74,7 +284,34 @@ func renderComments(comments []model.Comment, width int) string {
 	return b.String()
 }
 
+// commentAuthor returns the comment author's display name.
+func commentAuthor(c *model.Comment) string {
+	if c.Author != nil {
+		if c.Author.FullName != "" {
+			return c.Author.FullName
+		}
+		if c.Author.Login != "" {
+			return c.Author.Login
+		}
+	}
+	return "Unknown"
+}
+
 func formatTimestamp(ms int64) string {
 	t := time.UnixMilli(ms)
 	return t.Format("2006-01-02 15:04")
 }
+
+// formatSize renders a byte count in human-readable units.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cf/lazytrack/internal/api"
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func detailTestIssue() *model.Issue {
+	return &model.Issue{
+		IDReadable: "PROJ-1",
+		Summary:    "Crash",
+		Project:    &model.Project{ID: "0-1", Name: "Project", ShortName: "PROJ"},
+		CustomFields: []model.CustomField{
+			makeCustomField("State", "StateIssueCustomField", "Open"),
+			{Name: "Assignee", Type: "SingleUserIssueCustomField", Value: json.RawMessage(`{"login":"jane","fullName":"Jane Doe"}`)},
+			makeCustomField("Priority", "SingleEnumIssueCustomField", "Major"),
+			{Name: "Fix versions", Type: "MultiVersionIssueCustomField", Value: json.RawMessage(`[{"name":"1.0"},{"name":"1.1"}]`)},
+			{Name: "Due Date", Type: "DateIssueCustomField", Value: json.RawMessage(`null`)},
+			{Name: "Estimation", Type: "PeriodIssueCustomField", Value: json.RawMessage(`{"minutes":90}`)},
+		},
+	}
+}
+
+func TestDetailFields_ProjectOrder(t *testing.T) {
+	var priority, estimation model.ProjectCustomField
+	priority.Field.Name = "Priority"
+	estimation.Field.Name = "Estimation"
+	fields := fieldsLayout{order: []model.ProjectCustomField{priority, estimation}}
+
+	var names []string
+	for _, e := range detailFields(detailTestIssue(), fields) {
+		names = append(names, e.name)
+	}
+	want := "Priority Estimation State Assignee Fix versions Due Date"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("fields in order %q, want %q", got, want)
+	}
+
+	fields.hideEmpty = true
+	for _, e := range detailFields(detailTestIssue(), fields) {
+		if e.name == "Due Date" {
+			t.Error("expected empty Due Date to be hidden")
+		}
+	}
+}
+
+func TestRenderCustomFields_TwoColumns(t *testing.T) {
+	block := renderCustomFields(detailTestIssue(), fieldsLayout{}, 80)
+	lines := strings.Split(block, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 6 fields in 3 rows, got:\n%s", block)
+	}
+	for _, want := range []string{"State:", "Open", "Jane Doe", "1.0, 1.1", "1h 30m"} {
+		if !strings.Contains(block, want) {
+			t.Errorf("expected %q in:\n%s", want, block)
+		}
+	}
+	if !strings.Contains(lines[0], "State:") || !strings.Contains(lines[0], "Fix versions:") {
+		t.Errorf("expected columns filled top to bottom, got first row %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "Due Date:     —") {
+		t.Errorf("expected a dash for the empty date, got %q", lines[1])
+	}
+
+	narrow := renderCustomFields(detailTestIssue(), fieldsLayout{}, 40)
+	if n := len(strings.Split(narrow, "\n")); n != 6 {
+		t.Errorf("expected one column when narrow, got %d rows", n)
+	}
+}
+
+// workListService counts work item fetches.
+type workListService struct {
+	mockService
+	workCalls int
+}
+
+func (s *workListService) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
+	return &model.Issue{IDReadable: issueID}, nil
+}
+
+func (s *workListService) ListWorkItems(ctx context.Context, issueID string) ([]model.WorkItem, error) {
+	s.workCalls++
+	return []model.WorkItem{{Duration: model.Period{Minutes: 30}}}, nil
+}
+
+func TestWorkItems_FetchedOnlyWhileShown(t *testing.T) {
+	svc := &workListService{}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+
+	app.Update(app.fetchDetailCmd("PROJ-1")())
+	if svc.workCalls != 0 {
+		t.Fatalf("got %d work item fetches with the section hidden, want 0", svc.workCalls)
+	}
+
+	app.Update(app.toggleWorkItems()())
+	if svc.workCalls != 1 || len(app.workItems) != 1 {
+		t.Fatalf("expected work items loaded on open, got %d calls, %d items", svc.workCalls, len(app.workItems))
+	}
+	app.Update(app.fetchDetailCmd("PROJ-2")())
+	if svc.workCalls != 2 {
+		t.Errorf("got %d work item fetches, want 2", svc.workCalls)
+	}
+
+	if cmd := app.toggleWorkItems(); cmd != nil || app.workItems != nil {
+		t.Error("expected work items dropped without a fetch on close")
+	}
+}
+
+// failingFieldsService fails the admin custom fields endpoint with err.
+type failingFieldsService struct {
+	mockService
+	err        error
+	fieldCalls int
+}
+
+func (s *failingFieldsService) ListProjectCustomFields(ctx context.Context, projectID string) ([]model.ProjectCustomField, error) {
+	s.fieldCalls++
+	return nil, s.err
+}
+
+func TestProjectFields_TransientFailureIsRetried(t *testing.T) {
+	svc := &failingFieldsService{err: &api.Error{StatusCode: 502}}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+
+	for _, id := range []string{"PROJ-1", "PROJ-2"} {
+		issue := detailTestIssue()
+		issue.IDReadable = id
+		_, cmd := app.Update(issueDetailLoadedMsg{issue: issue})
+		runCmd(app, cmd)
+	}
+	if svc.fieldCalls != 2 {
+		t.Errorf("got %d custom field requests, want 2", svc.fieldCalls)
+	}
+}
+
+func TestProjectFields_FailureIsCached(t *testing.T) {
+	svc := &failingFieldsService{err: &api.Error{StatusCode: 403}}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+
+	for _, id := range []string{"PROJ-1", "PROJ-2", "PROJ-1"} {
+		issue := detailTestIssue()
+		issue.IDReadable = id
+		_, cmd := app.Update(issueDetailLoadedMsg{issue: issue})
+		runCmd(app, cmd)
+	}
+	if svc.fieldCalls != 1 {
+		t.Errorf("got %d custom field requests, want 1", svc.fieldCalls)
+	}
+	if app.err != "" {
+		t.Errorf("expected the failure not reported, got %q", app.err)
+	}
+}
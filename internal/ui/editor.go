@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/cf/lazytrack/internal/model"
 )
@@ -23,44 +24,26 @@ func resolveEditor() string {
 	return "vi"
 }
 
-// writeIssueTempFile writes an issue to a temp file in front matter + body format.
-// Returns the temp file path.
-func writeIssueTempFile(issue *model.Issue) (string, error) {
-	assignee := ""
-	if u := issue.AssigneeValue(); u != nil {
-		assignee = u.Login
-	}
-
-	content := fmt.Sprintf(`---
-summary: %s
-state: %s
-assignee: %s
-type: %s
----
-
-%s
-`, issue.Summary, issue.StateValue(), assignee, issue.TypeValue(), issue.Description)
-
-	f, err := os.CreateTemp("", fmt.Sprintf("lazytrack-edit-%s-*.md", issue.IDReadable))
+// writeIssueTempFile writes an issue to a temp file in YAML front matter +
+// body format, listing every field of its project. Returns the temp file
+// path.
+func writeIssueTempFile(issue *model.Issue, projectFields []model.ProjectCustomField) (string, error) {
+	content, err := formatIssueDraft(issueFrontMatterOf(issue), false, issue.Description, projectFields)
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
-
-	if _, err := f.WriteString(content); err != nil {
-		os.Remove(f.Name())
-		return "", err
-	}
-
-	return f.Name(), nil
+	return writeDraftTempFile(issue, content, "")
 }
 
 // parsedIssue holds the values extracted from an edited temp file.
 type parsedIssue struct {
+	project     string // short name; only used when creating
 	summary     string
 	state       string
 	assignee    string
 	issueType   string
+	tags        []string            // nil when the tags key was left out
+	fields      map[string][]string // other custom fields by name; no values clears
 	description string
 }
 
@@ -70,42 +53,56 @@ func parseIssueTempFile(path string) (parsedIssue, error) {
 	if err != nil {
 		return parsedIssue{}, err
 	}
+	return parseIssueDraft(string(data))
+}
 
-	content := string(data)
-	parts := strings.SplitN(content, "---\n", 3)
-	if len(parts) < 3 {
-		return parsedIssue{}, fmt.Errorf("malformed file: missing front matter delimiters")
+// newIssueTemplate returns the draft for an issue created in the editor,
+// with the project pre-filled and its fields listed blank.
+func newIssueTemplate(project string, projectFields []model.ProjectCustomField) (string, error) {
+	return formatIssueDraft(issueFrontMatter{Project: project}, true, "", projectFields)
+}
+
+// editorErrorHeader explains why an issue draft was not saved. It is placed
+// above the front matter when the editor re-opens.
+func editorErrorHeader(problem string) string {
+	problem = strings.ReplaceAll(problem, "-->", "-- >")
+	return editorHeaderStart + ": the issue was not saved.\n" +
+		"Error: " + problem + "\n" +
+		"Fix it and save to try again, or delete everything to cancel.\n" +
+		"-->\n\n"
+}
+
+// writeDraftTempFile writes an issue draft, for original or for a new issue
+// when original is nil, headed by the problem that stopped the last attempt
+// to save it, if any. Returns the temp file path.
+func writeDraftTempFile(original *model.Issue, content, problem string) (string, error) {
+	if problem != "" {
+		content = editorErrorHeader(problem) + content
 	}
 
-	var p parsedIssue
-	for _, line := range strings.Split(parts[1], "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		key, val, ok := strings.Cut(line, ":")
-		if !ok {
-			continue
-		}
-		switch strings.TrimSpace(key) {
-		case "summary":
-			p.summary = strings.TrimSpace(val)
-		case "state":
-			p.state = strings.TrimSpace(val)
-		case "assignee":
-			p.assignee = strings.TrimSpace(val)
-		case "type":
-			p.issueType = strings.TrimSpace(val)
-		}
+	pattern := "lazytrack-new-*.md"
+	if original != nil {
+		pattern = fmt.Sprintf("lazytrack-edit-%s-*.md", original.IDReadable)
+	}
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", err
 	}
 
-	p.description = strings.TrimSpace(parts[2])
-	return p, nil
+	return f.Name(), nil
 }
 
 // buildEditorUpdateFields compares parsed values against the original issue
-// and returns a fields map for UpdateIssue. Returns nil if nothing changed.
-func buildEditorUpdateFields(original *model.Issue, parsed parsedIssue) map[string]any {
+// and returns a fields map for UpdateIssue. Changed values of bundle fields
+// are checked against the project's fields when known. Returns nil if
+// nothing changed.
+func buildEditorUpdateFields(original *model.Issue, parsed parsedIssue, projectFields []model.ProjectCustomField) (map[string]any, error) {
 	fields := map[string]any{}
 	var customFields []map[string]any
 
@@ -118,25 +115,19 @@ func buildEditorUpdateFields(original *model.Issue, parsed parsedIssue) map[stri
 	}
 
 	if parsed.state != original.StateValue() {
-		customFields = append(customFields, map[string]any{
-			"name":  "State",
-			"$type": original.StateFieldType(),
-			"value": map[string]string{
-				"name":  parsed.state,
-				"$type": "StateBundleElement",
-			},
-		})
+		cf, err := customFieldPayload("State", original.StateFieldType(), findProjectField(projectFields, "State"), nonEmpty(parsed.state))
+		if err != nil {
+			return nil, err
+		}
+		customFields = append(customFields, cf)
 	}
 
 	if parsed.issueType != original.TypeValue() {
-		customFields = append(customFields, map[string]any{
-			"name":  "Type",
-			"$type": original.TypeFieldType(),
-			"value": map[string]string{
-				"name":  parsed.issueType,
-				"$type": "EnumBundleElement",
-			},
-		})
+		cf, err := customFieldPayload("Type", original.TypeFieldType(), findProjectField(projectFields, "Type"), nonEmpty(parsed.issueType))
+		if err != nil {
+			return nil, err
+		}
+		customFields = append(customFields, cf)
 	}
 
 	origAssignee := ""
@@ -162,12 +153,118 @@ func buildEditorUpdateFields(original *model.Issue, parsed parsedIssue) map[stri
 		}
 	}
 
+	current := map[string]model.CustomField{}
+	for _, cf := range original.CustomFields {
+		current[cf.Name] = cf
+	}
+	for _, name := range sortedKeys(parsed.fields) {
+		values := parsed.fields[name]
+		pf := findProjectField(projectFields, name)
+		orig, ok := current[name]
+		switch {
+		case coreFields[name]:
+			return nil, fmt.Errorf("set %s with its own key, not under fields", name)
+		case !ok && pf == nil:
+			return nil, fmt.Errorf("unknown field %q", name)
+		case sameValues(customFieldStrings(orig), values):
+			continue // unchanged, or left blank on a field the issue lacks
+		}
+		fieldType := orig.Type
+		if fieldType == "" && pf != nil {
+			fieldType = pf.IssueFieldType()
+		}
+		cf, err := customFieldPayload(name, fieldType, pf, values)
+		if err != nil {
+			return nil, err
+		}
+		customFields = append(customFields, cf)
+	}
+
 	if len(customFields) > 0 {
 		fields["customFields"] = customFields
 	}
 
 	if len(fields) == 0 {
-		return nil
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// writeCommentTempFile writes comment text to a markdown temp file for
+// editing. Returns the temp file path.
+func writeCommentTempFile(issueID, text string) (string, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("lazytrack-comment-%s-*.md", issueID))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(text); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// readCommentTempFile returns the edited comment text without the context
+// header and with surrounding whitespace trimmed.
+func readCommentTempFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stripEditorHeader(string(data))), nil
+}
+
+const (
+	editorHeaderStart     = "<!-- lazytrack"
+	maxHeaderComments     = 3
+	maxHeaderCommentLines = 8
+)
+
+// commentHeader renders an HTML comment block with the issue summary and
+// its last few comments, shown above a new comment for context. Anything
+// that would close the block early is defused.
+func commentHeader(issue *model.Issue, now time.Time) string {
+	defuse := func(s string) string {
+		return strings.ReplaceAll(s, "-->", "-- >")
+	}
+
+	var b strings.Builder
+	b.WriteString(editorHeaderStart + ": this block is ignored. Write your comment below it;\n")
+	b.WriteString("save an empty comment to cancel.\n\n")
+	b.WriteString(defuse(issue.IDReadable+": "+issue.Summary) + "\n")
+
+	comments := issue.Comments
+	if len(comments) > maxHeaderComments {
+		comments = comments[len(comments)-maxHeaderComments:]
+	}
+	if len(comments) > 0 {
+		b.WriteString("\nRecent comments:\n")
+	}
+	for _, c := range comments {
+		b.WriteString("\n" + defuse(commentAuthor(&c)) + " · " + relativeTime(c.Created, now) + "\n")
+		lines := strings.Split(strings.TrimSpace(c.Text), "\n")
+		if len(lines) > maxHeaderCommentLines {
+			lines = append(lines[:maxHeaderCommentLines], "…")
+		}
+		for _, line := range lines {
+			b.WriteString("  " + defuse(line) + "\n")
+		}
+	}
+	b.WriteString("-->\n\n")
+	return b.String()
+}
+
+// stripEditorHeader removes a leading header written by commentHeader or
+// editorErrorHeader.
+func stripEditorHeader(text string) string {
+	if !strings.HasPrefix(text, editorHeaderStart) {
+		return text
+	}
+	if _, rest, ok := strings.Cut(text, "-->"); ok {
+		return rest
 	}
-	return fields
+	return text
 }
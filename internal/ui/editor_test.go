@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"encoding/json"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cf/lazytrack/internal/model"
 )
@@ -39,7 +42,7 @@ func TestWriteIssueTempFile(t *testing.T) {
 		makeCustomFieldUser("Assignee", "johndoe", "John Doe"),
 	}
 
-	path, err := writeIssueTempFile(issue)
+	path, err := writeIssueTempFile(issue, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -82,7 +85,7 @@ func TestWriteIssueTempFile_EmptyFields(t *testing.T) {
 		Description: "",
 	}
 
-	path, err := writeIssueTempFile(issue)
+	path, err := writeIssueTempFile(issue, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -204,7 +207,10 @@ func TestBuildEditorUpdateFields_NoChanges(t *testing.T) {
 		assignee:    "alice",
 	}
 
-	fields := buildEditorUpdateFields(issue, parsed)
+	fields, err := buildEditorUpdateFields(issue, parsed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if fields != nil {
 		t.Errorf("expected nil fields for no changes, got %v", fields)
 	}
@@ -226,7 +232,10 @@ func TestBuildEditorUpdateFields_SummaryChanged(t *testing.T) {
 		issueType:   "Bug",
 	}
 
-	fields := buildEditorUpdateFields(issue, parsed)
+	fields, err := buildEditorUpdateFields(issue, parsed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if fields == nil {
 		t.Fatal("expected non-nil fields")
 	}
@@ -253,7 +262,10 @@ func TestBuildEditorUpdateFields_AssigneeCleared(t *testing.T) {
 		assignee:    "", // cleared
 	}
 
-	fields := buildEditorUpdateFields(issue, parsed)
+	fields, err := buildEditorUpdateFields(issue, parsed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if fields == nil {
 		t.Fatal("expected non-nil fields")
 	}
@@ -289,7 +301,10 @@ func TestBuildEditorUpdateFields_StateChanged(t *testing.T) {
 		issueType:   "Bug",
 	}
 
-	fields := buildEditorUpdateFields(issue, parsed)
+	fields, err := buildEditorUpdateFields(issue, parsed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if fields == nil {
 		t.Fatal("expected non-nil fields")
 	}
@@ -320,3 +335,338 @@ func makeCustomFieldUser(name, login, fullName string) model.CustomField {
 	v := []byte(`{"login": "` + login + `", "fullName": "` + fullName + `"}`)
 	return model.CustomField{Name: name, Value: v}
 }
+
+func TestCommentHeader(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	issue := &model.Issue{
+		IDReadable: "PROJ-1",
+		Summary:    "Crash on save",
+		Comments: []model.Comment{
+			{Text: "oldest", Author: &model.User{Login: "ann"}},
+			{Text: "a", Author: &model.User{Login: "ann"}},
+			{Text: "b\nends with -->", Author: &model.User{Login: "bob"}, Created: now.Add(-2 * time.Hour).UnixMilli()},
+			{Text: "c", Author: &model.User{Login: "cat"}},
+		},
+	}
+	header := commentHeader(issue, now)
+
+	if !strings.Contains(header, "PROJ-1: Crash on save") {
+		t.Error("expected issue summary in header")
+	}
+	if strings.Contains(header, "oldest") {
+		t.Error("expected only the last few comments")
+	}
+	if !strings.Contains(header, "bob · 2h ago") {
+		t.Errorf("expected author and age of comments, got:\n%s", header)
+	}
+	if strings.Count(header, "-->") != 1 {
+		t.Error("expected comment text not to close the header early")
+	}
+	if got := stripEditorHeader(header + "My reply\n"); strings.TrimSpace(got) != "My reply" {
+		t.Errorf("stripEditorHeader() = %q", got)
+	}
+}
+
+func TestReadCommentTempFile_StripsHeader(t *testing.T) {
+	path, err := writeCommentTempFile("PROJ-1", commentHeader(&model.Issue{IDReadable: "PROJ-1"}, time.Now())+"\n\nhello\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	text, err := readCommentTempFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "hello" {
+		t.Errorf("readCommentTempFile() = %q, want %q", text, "hello")
+	}
+
+	// A user's own leading HTML comment is kept.
+	os.WriteFile(path, []byte("<!-- note -->\nhello"), 0o600)
+	if text, _ := readCommentTempFile(path); text != "<!-- note -->\nhello" {
+		t.Errorf("readCommentTempFile() = %q", text)
+	}
+}
+
+func TestDraftTempFile_ErrorHeader(t *testing.T) {
+	draft := strings.Replace(mustTemplate(t, "PROJ"), "summary:", "summary: Crash", 1) + "Body\n"
+	path, err := writeDraftTempFile(nil, draft, "unknown state \"Done\"")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), editorHeaderStart) || !strings.Contains(string(data), `Error: unknown state "Done"`) {
+		t.Errorf("expected error header, got:\n%s", data)
+	}
+	if got := strings.TrimLeft(stripEditorHeader(string(data)), "\n"); got != draft {
+		t.Errorf("expected draft back after stripping header, got %q", got)
+	}
+
+	parsed, err := parseIssueTempFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.project != "PROJ" || parsed.summary != "Crash" || parsed.description != "Body" {
+		t.Errorf("unexpected parse: %+v", parsed)
+	}
+}
+
+func mustTemplate(t *testing.T, project string) string {
+	t.Helper()
+	template, err := newIssueTemplate(project, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return template
+}
+
+func TestIssueDraft_RoundTripTrickyValues(t *testing.T) {
+	issue := &model.Issue{
+		IDReadable:  "TEST-3",
+		Summary:     "# Crash: on save",
+		Description: "Intro\n---\nsummary: not front matter",
+		Tags:        []model.Tag{{Name: "needs: triage"}, {Name: "ui"}},
+		CustomFields: []model.CustomField{
+			makeCustomField("State", "StateIssueCustomField", "Open"),
+			makeCustomField("Priority", "SingleEnumIssueCustomField", "Major"),
+			{Name: "Fix versions", Type: "MultiVersionIssueCustomField", Value: json.RawMessage(`[{"name":"1.0"},{"name":"1.1"}]`)},
+			{Name: "Estimation", Type: "PeriodIssueCustomField", Value: json.RawMessage(`{"minutes":90,"presentation":"1h 30m"}`)},
+			{Name: "Due Date", Type: "DateIssueCustomField", Value: json.RawMessage(`null`)},
+		},
+	}
+	path, err := writeIssueTempFile(issue, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	parsed, err := parseIssueTempFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.summary != issue.Summary {
+		t.Errorf("summary = %q, want %q", parsed.summary, issue.Summary)
+	}
+	if parsed.description != issue.Description {
+		t.Errorf("description = %q, want %q", parsed.description, issue.Description)
+	}
+	if strings.Join(parsed.tags, "|") != "needs: triage|ui" {
+		t.Errorf("tags = %q", parsed.tags)
+	}
+	want := map[string]string{"Priority": "Major", "Fix versions": "1.0|1.1", "Estimation": "1h 30m", "Due Date": ""}
+	for name, values := range want {
+		if got := strings.Join(parsed.fields[name], "|"); got != values {
+			t.Errorf("fields[%s] = %q, want %q", name, got, values)
+		}
+	}
+	if _, ok := parsed.fields["State"]; ok {
+		t.Error("expected State only under its own key")
+	}
+
+	fields, err := buildEditorUpdateFields(issue, parsed, nil)
+	if err != nil || fields != nil {
+		t.Errorf("expected an untouched draft to change nothing, got %v, %v", fields, err)
+	}
+}
+
+func TestIssueDraft_DateWestOfUTC(t *testing.T) {
+	orig := time.Local
+	time.Local = time.FixedZone("PDT", -7*60*60)
+	defer func() { time.Local = orig }()
+
+	due := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	issue := &model.Issue{
+		IDReadable: "TEST-4",
+		Summary:    "Due",
+		CustomFields: []model.CustomField{
+			{Name: "Due Date", Type: "DateIssueCustomField", Value: json.RawMessage(strconv.FormatInt(due, 10))},
+		},
+	}
+	path, err := writeIssueTempFile(issue, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+
+	parsed, err := parseIssueTempFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(parsed.fields["Due Date"], "|"); got != "2025-07-01" {
+		t.Errorf("fields[Due Date] = %q, want 2025-07-01", got)
+	}
+}
+
+func TestParseIssueDraft_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"no closing delimiter": "---\nsummary: x\n",
+		"bad yaml":             "---\nsummary: [x\n---\n",
+		"nested field value":   "---\nfields:\n  Priority: {a: b}\n---\n",
+	} {
+		if _, err := parseIssueDraft(content); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBuildEditorUpdateFields_CustomFields(t *testing.T) {
+	var priority, due, estimation model.ProjectCustomField
+	priority.Field.Name = "Priority"
+	priority.Field.FieldType.ID = "enum[1]"
+	priority.Bundle.Values = []model.BundleValue{{Name: "Major", Type: "EnumBundleElement"}, {Name: "Critical", Type: "EnumBundleElement"}}
+	due.Field.Name = "Due Date"
+	due.Field.FieldType.ID = "date"
+	estimation.Field.Name = "Estimation"
+	estimation.Field.FieldType.ID = "period"
+	projectFields := []model.ProjectCustomField{priority, due, estimation}
+
+	issue := &model.Issue{
+		Summary: "Test",
+		CustomFields: []model.CustomField{
+			makeCustomField("Priority", "SingleEnumIssueCustomField", "Major"),
+			{Name: "Due Date", Type: "DateIssueCustomField", Value: json.RawMessage(`null`)},
+			{Name: "Estimation", Type: "PeriodIssueCustomField", Value: json.RawMessage(`{"minutes":60}`)},
+		},
+	}
+	parsed := parsedIssue{summary: "Test", fields: map[string][]string{
+		"Priority":   {"critical"},
+		"Due Date":   {"2025-07-01"},
+		"Estimation": nil,
+	}}
+
+	fields, err := buildEditorUpdateFields(issue, parsed, projectFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]map[string]any{}
+	for _, cf := range fields["customFields"].([]map[string]any) {
+		byName[cf["name"].(string)] = cf
+	}
+	if v := byName["Priority"]["value"].(map[string]string); v["name"] != "Critical" || byName["Priority"]["$type"] != "SingleEnumIssueCustomField" {
+		t.Errorf("unexpected priority payload %v", byName["Priority"])
+	}
+	wantDue := time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local).UnixMilli()
+	if byName["Due Date"]["value"] != wantDue {
+		t.Errorf("due date = %v, want %d", byName["Due Date"]["value"], wantDue)
+	}
+	if cf, ok := byName["Estimation"]; !ok || cf["value"] != nil {
+		t.Errorf("expected estimation cleared, got %v", cf)
+	}
+
+	parsed.fields = map[string][]string{"Priority": {"Blocker"}}
+	if _, err := buildEditorUpdateFields(issue, parsed, projectFields); err == nil || !strings.Contains(err.Error(), "Major, Critical") {
+		t.Errorf("expected unknown priority to be rejected, got %v", err)
+	}
+	parsed.fields = map[string][]string{"Severity": {"High"}}
+	if _, err := buildEditorUpdateFields(issue, parsed, projectFields); err == nil {
+		t.Error("expected unknown field to be rejected")
+	}
+	parsed.fields = map[string][]string{"Estimation": {"soon"}}
+	if _, err := buildEditorUpdateFields(issue, parsed, projectFields); err == nil {
+		t.Error("expected invalid duration to be rejected")
+	}
+}
+
+func TestIssueDraft_AllProjectFields(t *testing.T) {
+	field := func(name, fieldType string, values ...string) model.ProjectCustomField {
+		var f model.ProjectCustomField
+		f.Field.Name = name
+		f.Field.FieldType.ID = fieldType
+		for _, v := range values {
+			f.Bundle.Values = append(f.Bundle.Values, model.BundleValue{Name: v})
+		}
+		return f
+	}
+	projectFields := append(testProjectFields(),
+		field("Priority", "enum[1]", "Critical", "Major", "Minor"),
+		field("Fix Version", "version[*]", "1.0", "2.0"),
+		field("Subsystem", "ownedField[1]", "Backend", "UI"),
+		field("Reviewers", "user[*]"),
+		field("Due Date", "date"),
+		field("Estimation", "period"),
+		field("Story Points", "integer"),
+	)
+	issue := &model.Issue{
+		IDReadable: "PROJ-4",
+		Summary:    "Test",
+		CustomFields: []model.CustomField{
+			makeCustomField("Priority", "SingleEnumIssueCustomField", "Major"),
+			{Name: "Fix Version", Type: "MultiVersionIssueCustomField", Value: json.RawMessage(`[{"name":"1.0"}]`)},
+			{Name: "Reviewers", Type: "MultiUserIssueCustomField", Value: json.RawMessage(`[]`)},
+			{Name: "Story Points", Type: "SimpleIssueCustomField", Value: json.RawMessage(`3`)},
+		},
+	}
+
+	draft, err := formatIssueDraft(issueFrontMatterOf(issue), false, "", projectFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"state: # one of: Open, In Progress",
+		"assignee: # login",
+		"Priority: Major # one of: Critical, Major, Minor",
+		"Fix Version: 1.0 # any of: 1.0, 2.0",
+		"Subsystem: # one of: Backend, UI",
+		"Reviewers: # logins",
+		"Due Date: # date: YYYY-MM-DD",
+		"Estimation: # duration, e.g. 1h 30m",
+		"Story Points: 3 # whole number",
+	} {
+		if !strings.Contains(draft, want) {
+			t.Errorf("draft missing %q:\n%s", want, draft)
+		}
+	}
+	if strings.Index(draft, "Priority:") > strings.Index(draft, "Fix Version:") ||
+		strings.Index(draft, "Due Date:") > strings.Index(draft, "Story Points:") {
+		t.Errorf("expected fields in project order:\n%s", draft)
+	}
+
+	parsed, err := parseIssueDraft(draft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields, err := buildEditorUpdateFields(issue, parsed, projectFields); err != nil || fields != nil {
+		t.Fatalf("expected an untouched draft to change nothing, got %v, %v", fields, err)
+	}
+
+	edited := strings.NewReplacer(
+		"Fix Version: 1.0", "Fix Version: [1.0, 2.0]",
+		"Subsystem:", "Subsystem: ui",
+		"Reviewers:", "Reviewers: [jane, '@bob']",
+		"Story Points: 3", "Story Points: 5",
+	).Replace(draft)
+	parsed, err = parseIssueDraft(edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := buildEditorUpdateFields(issue, parsed, projectFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]map[string]any{}
+	for _, cf := range fields["customFields"].([]map[string]any) {
+		byName[cf["name"].(string)] = cf
+	}
+	if len(byName) != 4 {
+		t.Errorf("expected 4 changed fields, got %v", byName)
+	}
+	if v := byName["Fix Version"]["value"].([]map[string]string); len(v) != 2 || v[1]["$type"] != "VersionBundleElement" || byName["Fix Version"]["$type"] != "MultiVersionIssueCustomField" {
+		t.Errorf("unexpected fix version payload %v", byName["Fix Version"])
+	}
+	if v := byName["Subsystem"]["value"].(map[string]string); v["name"] != "UI" || byName["Subsystem"]["$type"] != "SingleOwnedIssueCustomField" {
+		t.Errorf("unexpected subsystem payload %v", byName["Subsystem"])
+	}
+	if v := byName["Reviewers"]["value"].([]map[string]string); len(v) != 2 || v[1]["login"] != "bob" || v[1]["$type"] != "User" {
+		t.Errorf("unexpected reviewers payload %v", byName["Reviewers"])
+	}
+	if byName["Story Points"]["value"] != 5 || byName["Story Points"]["$type"] != "SimpleIssueCustomField" {
+		t.Errorf("unexpected story points payload %v", byName["Story Points"])
+	}
+
+	parsed.fields = map[string][]string{"Story Points": {"lots"}}
+	if _, err := buildEditorUpdateFields(issue, parsed, projectFields); err == nil || !strings.Contains(err.Error(), "Story Points") {
+		t.Errorf("expected a non-numeric integer to be rejected, got %v", err)
+	}
+}
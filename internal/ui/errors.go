@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cf/lazytrack/internal/api"
+)
+
+// describeError turns an error into a message fit for the status bar.
+// API errors are reduced to what the user can act on; anything else is
+// shown as-is.
+func describeError(err error) string {
+	apiErr, ok := api.AsError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	detail := apiErr.Message()
+	withDetail := func(s string) string {
+		if detail == "" {
+			return s
+		}
+		return s + ": " + detail
+	}
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return "Authentication failed — token expired or revoked"
+	case apiErr.StatusCode == http.StatusForbidden:
+		return withDetail("Permission denied")
+	case apiErr.StatusCode == http.StatusNotFound:
+		return withDetail("Not found")
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		return "Rate limited by YouTrack — try again shortly"
+	case apiErr.StatusCode >= 500:
+		return fmt.Sprintf("YouTrack server error (HTTP %d) — try again later", apiErr.StatusCode)
+	case detail != "":
+		return detail
+	default:
+		return apiErr.Error()
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/api"
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// failingService returns err from GetIssue.
+type failingService struct {
+	mockService
+	err error
+}
+
+func (f *failingService) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
+	return nil, f.err
+}
+
+func TestDescribeError(t *testing.T) {
+	wrap := func(e *api.Error) error { return fmt.Errorf("fetching issue: %w", e) }
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain", errors.New("boom"), "boom"},
+		{"401", wrap(&api.Error{StatusCode: 401, Code: "Unauthorized"}), "Authentication failed — token expired or revoked"},
+		{"403", wrap(&api.Error{StatusCode: 403, Description: "Not allowed in PROJ"}), "Permission denied: Not allowed in PROJ"},
+		{"404", wrap(&api.Error{StatusCode: 404}), "Not found"},
+		{"400", wrap(&api.Error{StatusCode: 400, Description: "Summary is required"}), "Summary is required"},
+		{"429", wrap(&api.Error{StatusCode: 429}), "Rate limited by YouTrack — try again shortly"},
+		{"503", wrap(&api.Error{StatusCode: 503, Body: "<html>"}), "YouTrack server error (HTTP 503) — try again later"},
+		{"422 no detail", wrap(&api.Error{StatusCode: 422}), "API error (HTTP 422)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeError(tt.err); got != tt.want {
+				t.Errorf("describeError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchDetail_NotFound(t *testing.T) {
+	svc := &failingService{err: fmt.Errorf("fetching issue: %w", &api.Error{StatusCode: 404})}
+	app := NewApp(svc, config.DefaultState())
+
+	msg := app.fetchDetailCmd("PROJ-123")()
+	m, _ := app.Update(msg)
+	a := m.(*App)
+
+	if a.err != "PROJ-123 not found" {
+		t.Errorf("got err %q, want %q", a.err, "PROJ-123 not found")
+	}
+	if a.authFailed {
+		t.Error("404 should not be treated as an auth failure")
+	}
+}
+
+func TestAuthFailure_EnterRerunsSetup(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.statePath = t.TempDir() + "/state.yaml"
+	app.ready = true
+	app.width = 120
+	app.height = 40
+
+	m, _ := app.Update(errMsg{fmt.Errorf("listing issues: %w", &api.Error{StatusCode: 401})})
+	a := m.(*App)
+	if !a.authFailed {
+		t.Fatal("expected authFailed after a 401")
+	}
+	if !strings.Contains(a.err, "re-run setup") {
+		t.Errorf("expected setup hint in %q", a.err)
+	}
+
+	m, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = m.(*App)
+	if !a.RerunSetup() {
+		t.Error("expected RerunSetup after enter")
+	}
+	if cmd == nil {
+		t.Fatal("expected quit cmd")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected tea.Quit")
+	}
+}
+
+func TestAuthFailure_OtherKeyDismisses(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+
+	m, _ := app.Update(errMsg{&api.Error{StatusCode: 401}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	a := m.(*App)
+
+	if a.err != "" || a.authFailed {
+		t.Errorf("expected error dismissed, got err=%q authFailed=%v", a.err, a.authFailed)
+	}
+	if a.RerunSetup() {
+		t.Error("esc should not re-run setup")
+	}
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// issueFrontMatter is the YAML front matter of an issue temp file. Custom
+// fields other than state, assignee and type go under fields, by name.
+type issueFrontMatter struct {
+	Project  string                 `yaml:"project"`
+	Summary  string                 `yaml:"summary"`
+	State    string                 `yaml:"state"`
+	Assignee string                 `yaml:"assignee"`
+	Type     string                 `yaml:"type"`
+	Tags     []string               `yaml:"tags"`
+	Fields   map[string]fieldValues `yaml:"fields"`
+}
+
+// fieldValues holds a custom field's values as written in front matter:
+// a scalar for one value, a list for several, empty to clear the field.
+type fieldValues []string
+
+func (v *fieldValues) UnmarshalYAML(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		*v = nil
+		if n.Tag != "!!null" && strings.TrimSpace(n.Value) != "" {
+			*v = fieldValues{strings.TrimSpace(n.Value)}
+		}
+		return nil
+	case yaml.SequenceNode:
+		*v = nil
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: expected a list of values", item.Line)
+			}
+			if s := strings.TrimSpace(item.Value); s != "" {
+				*v = append(*v, s)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d: expected a value or a list of values", n.Line)
+	}
+}
+
+// formatIssueDraft renders front matter and description as an issue temp
+// file. The project key is only written when creating. Every field in
+// projectFields is listed under fields in project order, blank if unset,
+// with the values it accepts in a comment.
+func formatIssueDraft(fm issueFrontMatter, creating bool, description string, projectFields []model.ProjectCustomField) (string, error) {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, value *yaml.Node, hint string) {
+		value.LineComment = hint
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+
+	if creating {
+		add("project", stringNode(fm.Project), "")
+	}
+	add("summary", stringNode(fm.Summary), "")
+	add("state", stringNode(fm.State), fieldHint(findProjectField(projectFields, "State")))
+	add("assignee", stringNode(fm.Assignee), fieldHint(findProjectField(projectFields, "Assignee")))
+	add("type", stringNode(fm.Type), fieldHint(findProjectField(projectFields, "Type")))
+	add("tags", listNode(fm.Tags), "")
+
+	fields := &yaml.Node{Kind: yaml.MappingNode}
+	listed := map[string]bool{}
+	for i := range projectFields {
+		pf := &projectFields[i]
+		name := pf.Field.Name
+		if coreFields[name] || listed[name] {
+			continue
+		}
+		listed[name] = true
+		value := valuesNode(fm.Fields[name])
+		value.LineComment = fieldHint(pf)
+		fields.Content = append(fields.Content, stringNode(name), value)
+	}
+	var others []string
+	for name := range fm.Fields {
+		if !listed[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		fields.Content = append(fields.Content, stringNode(name), valuesNode(fm.Fields[name]))
+	}
+	example := ""
+	if len(fields.Content) == 0 {
+		fields.Style = yaml.FlowStyle
+		example = "e.g. {Priority: Major, Due Date: 2025-07-01, Estimation: 4h}"
+	}
+	add("fields", fields, example)
+
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return "---\n" + b.String() + "---\n\n" + description + "\n", nil
+}
+
+// stringNode renders s as a YAML string, quoted where needed and left
+// blank when empty.
+func stringNode(s string) *yaml.Node {
+	if s == "" {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// listNode renders values as a flow sequence, e.g. [a, b].
+func listNode(values []string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, v := range values {
+		n.Content = append(n.Content, stringNode(v))
+	}
+	return n
+}
+
+// valuesNode renders a custom field's values: blank, a scalar or a list.
+// Numbers are left unquoted; they read back as the same text.
+func valuesNode(values fieldValues) *yaml.Node {
+	switch len(values) {
+	case 0:
+		return stringNode("")
+	case 1:
+		return valueNode(values[0])
+	default:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, v := range values {
+			n.Content = append(n.Content, valueNode(v))
+		}
+		return n
+	}
+}
+
+func valueNode(s string) *yaml.Node {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: s}
+	}
+	return stringNode(s)
+}
+
+// parseIssueDraft extracts front matter and description from the contents
+// of an issue temp file. An editor header above the front matter is
+// ignored, and only the first closing "---" ends the front matter, so the
+// description may contain its own.
+func parseIssueDraft(content string) (parsedIssue, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimLeft(stripEditorHeader(content), " \t\n")
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return parsedIssue{}, fmt.Errorf("malformed file: missing front matter delimiters")
+	}
+	header, body, ok := strings.Cut("\n"+rest, "\n---\n")
+	if !ok {
+		header, ok = strings.CutSuffix("\n"+rest, "\n---")
+	}
+	if !ok {
+		return parsedIssue{}, fmt.Errorf("malformed file: missing front matter delimiters")
+	}
+
+	var fm issueFrontMatter
+	if err := yaml.Unmarshal([]byte(header), &fm); err != nil {
+		return parsedIssue{}, fmt.Errorf("front matter: %w", err)
+	}
+
+	p := parsedIssue{
+		project:     strings.TrimSpace(fm.Project),
+		summary:     strings.TrimSpace(fm.Summary),
+		state:       strings.TrimSpace(fm.State),
+		assignee:    strings.TrimSpace(fm.Assignee),
+		issueType:   strings.TrimSpace(fm.Type),
+		description: strings.TrimSpace(body),
+	}
+	if fm.Tags != nil {
+		p.tags = []string{}
+		for _, t := range fm.Tags {
+			if t = strings.TrimSpace(t); t != "" {
+				p.tags = append(p.tags, t)
+			}
+		}
+	}
+	if len(fm.Fields) > 0 {
+		p.fields = make(map[string][]string, len(fm.Fields))
+		for name, values := range fm.Fields {
+			p.fields[strings.TrimSpace(name)] = values
+		}
+	}
+	return p, nil
+}
+
+// maxHintValues caps the allowed values listed in a field's comment.
+const maxHintValues = 15
+
+// fieldHint describes the values a project field accepts, for a comment
+// next to it in the draft: the bundle's values, or the expected format.
+func fieldHint(pf *model.ProjectCustomField) string {
+	if pf == nil {
+		return ""
+	}
+	if model.BundleElementType(pf.Kind()) != "" {
+		values := pf.Bundle.Values
+		if len(values) == 0 {
+			return ""
+		}
+		names := bundleNames(values[:min(len(values), maxHintValues)])
+		if len(values) > maxHintValues {
+			names += ", …"
+		}
+		if pf.Multi() {
+			return "any of: " + names
+		}
+		return "one of: " + names
+	}
+	switch pf.Kind() {
+	case "user":
+		if pf.Multi() {
+			return "logins"
+		}
+		return "login"
+	case "date":
+		return "date: YYYY-MM-DD"
+	case "period":
+		return "duration, e.g. 1h 30m"
+	case "integer":
+		return "whole number"
+	case "float":
+		return "number"
+	case "string", "text":
+		return "text"
+	}
+	return ""
+}
+
+// coreFields are edited through their own front matter keys.
+var coreFields = map[string]bool{"State": true, "Type": true, "Assignee": true}
+
+// issueFrontMatterOf returns the front matter describing an issue's
+// current values.
+func issueFrontMatterOf(issue *model.Issue) issueFrontMatter {
+	fm := issueFrontMatter{
+		Summary: issue.Summary,
+		State:   issue.StateValue(),
+		Type:    issue.TypeValue(),
+		Tags:    []string{},
+		Fields:  map[string]fieldValues{},
+	}
+	if u := issue.AssigneeValue(); u != nil {
+		fm.Assignee = u.Login
+	}
+	for _, t := range issue.Tags {
+		fm.Tags = append(fm.Tags, t.Name)
+	}
+	for _, cf := range issue.CustomFields {
+		if !coreFields[cf.Name] {
+			fm.Fields[cf.Name] = customFieldStrings(cf)
+		}
+	}
+	return fm
+}
+
+// customFieldStrings returns a custom field's values as written in front
+// matter: bundle values and users by name or login, dates as YYYY-MM-DD
+// and periods like "1h 30m".
+func customFieldStrings(cf model.CustomField) []string {
+	v, err := cf.Decode()
+	if err != nil {
+		return nil
+	}
+	return v.Strings()
+}
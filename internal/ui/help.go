@@ -16,23 +16,55 @@ Direct Actions:
   #           Go to issue by number
   r           Refresh
   H/L         Resize panels
+  z           Fold/unfold subtasks (tree mode)
 
 Leader Actions (space + key):
   space c     Create issue
+  space C     Create issue in $EDITOR
   space e     Edit issue
   space d     Delete issue
   space m     Add comment
+  space M     Write comment in $EDITOR
   space s     Set state
+  space S     Select sprint / add issue to sprint
   space a     Assign issue
+  space b     Agile board
   space p     Select project
+  space /     Saved searches
   space f     Find issue
+  space F     Show/hide empty fields
+  space g     Add/remove tags
+  space h     Toggle subtask tree
+  space H     Toggle history panel
+  space l     Links (jump/add/remove)
   space n     Mentions
+  space o     Attachments (open/save)
+  space u     Upload attachment
   space t     Toggle issue list
+  space T     Start/stop work timer
   space v     Vim edit issue
+  space w     Log work
+  space W     Show/hide work items
+
+Search Prompt:
+  tab         Accept completion (ctrl+n/ctrl+p to pick, esc to hide)
+  up/down     Previous/next query from history
+  ctrl+r      Fuzzy search query history (enter run, tab edit)
+
+Comments Panel:
+  j/k         Select comment
+  e           Edit comment
+  E           Edit comment in $EDITOR
+  a           Quote-reply to comment
+  A           Quote-reply in $EDITOR
+  x           Delete comment
+  y           Copy comment text
+  o           Open comment in browser
 
 Dialogs & Comments:
   tab/shift+tab   Navigate fields
   ctrl+s          Submit
+  @               Mention a user (up/down, enter/tab)
   esc             Cancel
 
 General:
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// historyService serves a fixed activity feed and counts fetches.
+type historyService struct {
+	mockService
+	calls []string
+	err   error
+}
+
+func (s *historyService) ListActivities(ctx context.Context, issueID string) ([]model.Activity, error) {
+	s.calls = append(s.calls, issueID)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []model.Activity{
+		{
+			Timestamp: 1707300000000,
+			Author:    &model.User{Login: "jane", FullName: "Jane Doe"},
+			Category:  model.ActivityCategory{ID: model.ActivityCustomField},
+			Field:     &model.ActivityField{Name: "State"},
+			Removed:   json.RawMessage(`[{"name":"Open"}]`),
+			Added:     json.RawMessage(`[{"name":"Fixed"}]`),
+		},
+	}, nil
+}
+
+func newHistoryTestApp(svc IssueService) *App {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 160
+	app.height = 40
+	app.Update(issueDetailLoadedMsg{issue: &model.Issue{
+		IDReadable: "PROJ-1",
+		Comments:   []model.Comment{{ID: "c1", Text: "hello"}},
+	}})
+	return app
+}
+
+func toggleHistoryKey(app *App) tea.Cmd {
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	return cmd
+}
+
+func TestHistory_ToggleLoadsFeed(t *testing.T) {
+	svc := &historyService{}
+	app := newHistoryTestApp(svc)
+	app.focus = commentsPane
+
+	cmd := toggleHistoryKey(app)
+	if !app.showHistory {
+		t.Fatal("expected history shown after space H")
+	}
+	if app.focus != historyPane {
+		t.Errorf("expected focus to move from comments to history, got %v", app.focus)
+	}
+	if cmd == nil {
+		t.Fatal("expected history fetch")
+	}
+	app.Update(cmd())
+
+	if len(svc.calls) != 1 || svc.calls[0] != "PROJ-1" {
+		t.Errorf("unexpected history fetches: %v", svc.calls)
+	}
+	view := app.View()
+	if !strings.Contains(view, "History (1)") {
+		t.Error("expected history panel title in view")
+	}
+	if !strings.Contains(view, "State: Open → Fixed") || !strings.Contains(view, "Jane Doe") {
+		t.Error("expected activity with author in history panel")
+	}
+
+	toggleHistoryKey(app)
+	if app.showHistory || app.focus != commentsPane {
+		t.Errorf("expected comments back with focus, got showHistory=%v focus=%v", app.showHistory, app.focus)
+	}
+	if !strings.Contains(app.View(), "Comments (1)") {
+		t.Error("expected comments panel after hiding history")
+	}
+}
+
+func TestHistory_ReloadsForNewSelection(t *testing.T) {
+	svc := &historyService{}
+	app := newHistoryTestApp(svc)
+	app.Update(toggleHistoryKey(app)())
+
+	_, cmd := app.Update(issueDetailLoadedMsg{issue: &model.Issue{IDReadable: "PROJ-2"}})
+	if cmd == nil {
+		t.Fatal("expected history fetch for the new issue")
+	}
+
+	// A late response for the previous issue is ignored
+	app.Update(historyLoadedMsg{issueID: "PROJ-1", activities: make([]model.Activity, 3)})
+	if app.activities != nil {
+		t.Error("expected stale history to be dropped")
+	}
+
+	app.Update(cmd())
+	if len(svc.calls) != 2 || svc.calls[1] != "PROJ-2" || len(app.activities) != 1 {
+		t.Errorf("unexpected history state: calls=%v activities=%d", svc.calls, len(app.activities))
+	}
+}
+
+func TestHistory_TabCyclesThroughHistory(t *testing.T) {
+	app := newHistoryTestApp(&historyService{})
+	toggleHistoryKey(app)
+	app.focus = detailPane
+
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.focus != historyPane {
+		t.Errorf("expected tab from detail to reach history, got %v", app.focus)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if app.focus != listPane {
+		t.Errorf("expected tab from history to reach list, got %v", app.focus)
+	}
+}
+
+func TestHistory_ErrorShownInPane(t *testing.T) {
+	svc := &historyService{err: errors.New("boom")}
+	app := newHistoryTestApp(svc)
+
+	app.Update(toggleHistoryKey(app)())
+
+	view := app.history.View()
+	if strings.Contains(view, "Loading history") {
+		t.Error("expected the loading placeholder replaced")
+	}
+	if !strings.Contains(view, "boom") {
+		t.Errorf("expected the error in the history pane, got %q", view)
+	}
+}
@@ -43,6 +43,9 @@ type IssueDialog struct {
 	// Issue being edited (nil for create)
 	issueID string
 
+	// Parent the new issue is created as a subtask of (create mode only)
+	parentID string
+
 	// Project selector (create mode only)
 	projects       []model.Project
 	projectIndex   int
@@ -66,6 +69,7 @@ type IssueDialog struct {
 	// Summary and Description
 	summaryInput textinput.Model
 	descInput    textarea.Model
+	mentions     MentionPicker // @mention suggestions for descInput
 
 	// Comments pane (edit mode)
 	comments     []model.Comment
@@ -104,8 +108,9 @@ func NewIssueDialog() IssueDialog {
 	}
 }
 
-// OpenCreate activates the dialog in create mode.
-func (d *IssueDialog) OpenCreate(projects []model.Project) tea.Cmd {
+// OpenCreate activates the dialog in create mode. With a non-nil parent the
+// new issue is created in the parent's project and linked as its subtask.
+func (d *IssueDialog) OpenCreate(projects []model.Project, parent *model.Issue) tea.Cmd {
 	d.mode = modeCreate
 	d.active = true
 	d.submitted = false
@@ -115,6 +120,19 @@ func (d *IssueDialog) OpenCreate(projects []model.Project) tea.Cmd {
 	d.projectIndex = 0
 	d.projectChanged = false
 
+	d.parentID = ""
+	if parent != nil {
+		d.parentID = parent.IDReadable
+		if parent.Project != nil {
+			for i, p := range projects {
+				if p.ID == parent.Project.ID {
+					d.projectIndex = i
+					break
+				}
+			}
+		}
+	}
+
 	d.typeValues = nil
 	d.typeCursor = 0
 	d.typeFieldType = ""
@@ -223,6 +241,7 @@ func (d *IssueDialog) Close() {
 	d.summaryInput.Blur()
 	d.descInput.Blur()
 	d.assigneeInput.Blur()
+	d.mentions.Reset()
 }
 
 // focusOrderIndex returns the position of focusIndex within fieldOrder.
@@ -361,6 +380,9 @@ func (d *IssueDialog) Update(msg tea.Msg) (IssueDialog, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if d.focusIndex == fieldDescription && d.mentions.HandleKey(msg, &d.descInput) {
+			return *d, nil
+		}
 		switch msg.String() {
 		case "esc":
 			d.Close()
@@ -497,7 +519,7 @@ func (d *IssueDialog) Update(msg tea.Msg) (IssueDialog, tea.Cmd) {
 		case fieldDescription:
 			var cmd tea.Cmd
 			d.descInput, cmd = d.descInput.Update(msg)
-			return *d, cmd
+			return *d, tea.Batch(cmd, d.mentions.Refresh(d.descInput))
 
 		case fieldComments:
 			switch msg.String() {
@@ -571,6 +593,9 @@ func (d *IssueDialog) View(width, height int) string {
 
 func (d *IssueDialog) formTitle() string {
 	if d.mode == modeCreate {
+		if d.parentID != "" {
+			return iconFile + " Create Subtask of " + d.parentID
+		}
 		return iconFile + " Create Issue"
 	}
 	return iconFile + " Edit " + d.issueID
@@ -673,7 +698,15 @@ func (d *IssueDialog) renderForm(width, height int) string {
 	d.descInput.SetWidth(width - 4)
 	d.descInput.SetHeight(descHeight)
 	b.WriteString(label("Description", fieldDescription) + "\n")
-	b.WriteString("  " + d.descInput.View() + "\n\n")
+	b.WriteString("  " + d.descInput.View() + "\n")
+	if d.focusIndex == fieldDescription {
+		for _, line := range strings.Split(strings.TrimSuffix(d.mentions.View(width-4), "\n"), "\n") {
+			if line != "" {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	b.WriteString("\n")
 
 	// Hint bar
 	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/api"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// openIssueEditor opens an issue draft in $EDITOR: changes to original, or a
+// new issue when original is nil. A non-empty problem is shown above the
+// draft as a comment, e.g. after a failed save.
+func (a *App) openIssueEditor(original *model.Issue, content, problem string) tea.Cmd {
+	tempPath, err := writeDraftTempFile(original, content, problem)
+	if err != nil {
+		a.err = "Failed to create temp file: " + err.Error()
+		return nil
+	}
+	return editIssueDraft(tempPath, original)
+}
+
+// editIssueDraft runs $EDITOR on a draft temp file.
+func editIssueDraft(tempPath string, original *model.Issue) tea.Cmd {
+	c := exec.Command(resolveEditor(), tempPath)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err, tempPath: tempPath, original: original}
+	})
+}
+
+// issueDraftCmd loads the project's custom fields and writes a draft that
+// lists all of them: original's values, or a blank issue in project when
+// original is nil. An unknown project gets a draft without fields.
+func (a *App) issueDraftCmd(original *model.Issue, project string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		projectID := ""
+		if original != nil && original.Project != nil {
+			projectID = original.Project.ID
+		} else if original == nil && project != "" {
+			projects, err := service.ListProjects(ctx)
+			if err != nil {
+				return errMsg{err}
+			}
+			if p := findProject(projects, project); p != nil {
+				projectID = p.ID
+			}
+		}
+
+		var fields []model.ProjectCustomField
+		if projectID != "" {
+			var err error
+			fields, err = service.ListProjectCustomFields(ctx, projectID)
+			if err != nil {
+				return errMsg{err}
+			}
+		}
+
+		var tempPath string
+		var err error
+		if original != nil {
+			tempPath, err = writeIssueTempFile(original, fields)
+		} else {
+			var template string
+			template, err = newIssueTemplate(project, fields)
+			if err == nil {
+				tempPath, err = writeDraftTempFile(nil, template, "")
+			}
+		}
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to create temp file: %w", err)}
+		}
+		return issueDraftReadyMsg{original: original, tempPath: tempPath}
+	}
+}
+
+// createFromEditorCmd resolves the draft's project, custom fields and tags
+// and creates the issue. Any failure before the issue exists hands the
+// draft back for another round in the editor rather than dropping it,
+// except an expired token, which goes to the setup flow.
+func (a *App) createFromEditorCmd(parsed parsedIssue, content string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		reject := func(problem string) tea.Msg {
+			return issueDraftRejectedMsg{content: content, problem: problem}
+		}
+		fail := func(err error) tea.Msg {
+			if api.IsUnauthorized(err) {
+				return errMsg{err}
+			}
+			return reject(describeError(err))
+		}
+
+		projects, err := service.ListProjects(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		project := findProject(projects, parsed.project)
+		if project == nil {
+			return reject(fmt.Sprintf("unknown project %q", parsed.project))
+		}
+		fields, err := service.ListProjectCustomFields(ctx, project.ID)
+		if err != nil {
+			return fail(err)
+		}
+		customFields, err := buildNewIssueFields(fields, parsed)
+		if err != nil {
+			return reject(err.Error())
+		}
+		tags, err := resolveTagNames(ctx, service, parsed.tags)
+		if err != nil {
+			return reject(err.Error())
+		}
+		created, err := service.CreateIssue(ctx, project.ID, parsed.summary, parsed.description, customFields)
+		if err != nil {
+			return fail(err)
+		}
+		if created != nil {
+			for _, tag := range tags {
+				if err := service.AddIssueTag(ctx, created.IDReadable, tag.ID); err != nil {
+					return errMsg{err}
+				}
+			}
+		}
+		return issueCreatedMsg{}
+	}
+}
+
+// updateFromEditorCmd validates the edited draft against the project's
+// fields and tags, then updates the issue. Problems found before anything
+// is changed hand the draft back to the editor; an expired token goes to
+// the setup flow instead.
+func (a *App) updateFromEditorCmd(original *model.Issue, parsed parsedIssue, content string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		reject := func(problem string) tea.Msg {
+			return issueDraftRejectedMsg{original: original, content: content, problem: problem}
+		}
+		fail := func(err error) tea.Msg {
+			if api.IsUnauthorized(err) {
+				return errMsg{err}
+			}
+			return reject(describeError(err))
+		}
+
+		var projectFields []model.ProjectCustomField
+		if original.Project != nil && original.Project.ID != "" {
+			var err error
+			projectFields, err = service.ListProjectCustomFields(ctx, original.Project.ID)
+			if err != nil {
+				return fail(err)
+			}
+		}
+		fields, err := buildEditorUpdateFields(original, parsed, projectFields)
+		if err != nil {
+			return reject(err.Error())
+		}
+		added, removed := tagChanges(original.Tags, parsed.tags)
+		addTags, err := resolveTagNames(ctx, service, added)
+		if err != nil {
+			return reject(err.Error())
+		}
+
+		issueID := original.IDReadable
+		if fields != nil {
+			if err := service.UpdateIssue(ctx, issueID, fields); err != nil {
+				return fail(err)
+			}
+		}
+		for _, tag := range addTags {
+			if err := service.AddIssueTag(ctx, issueID, tag.ID); err != nil {
+				return errMsg{err}
+			}
+		}
+		for _, tag := range removed {
+			if err := service.RemoveIssueTag(ctx, issueID, tag.ID); err != nil {
+				return errMsg{err}
+			}
+		}
+		return issueUpdatedMsg{}
+	}
+}
+
+// tagChanges compares an issue's tags with the tag names in a draft. A nil
+// draft list leaves the tags alone.
+func tagChanges(current []model.Tag, names []string) (added []string, removed []model.Tag) {
+	if names == nil {
+		return nil, nil
+	}
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[strings.ToLower(name)] = true
+	}
+	have := map[string]bool{}
+	for _, tag := range current {
+		have[strings.ToLower(tag.Name)] = true
+		if !wanted[strings.ToLower(tag.Name)] {
+			removed = append(removed, tag)
+		}
+	}
+	for _, name := range names {
+		if !have[strings.ToLower(name)] {
+			added = append(added, name)
+			have[strings.ToLower(name)] = true
+		}
+	}
+	return added, removed
+}
+
+// resolveTagNames looks up tags by name. Tags can't be created from the
+// editor, so an unknown name is an error.
+func resolveTagNames(ctx context.Context, service IssueService, names []string) ([]model.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	all, err := service.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading tags: %s", describeError(err))
+	}
+	var tags []model.Tag
+	for _, name := range names {
+		found := false
+		for _, tag := range all {
+			if strings.EqualFold(tag.Name, name) {
+				tags = append(tags, tag)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown tag %q", name)
+		}
+	}
+	return tags, nil
+}
+
+// findProject matches a project by short name or name, ignoring case.
+func findProject(projects []model.Project, name string) *model.Project {
+	for i, p := range projects {
+		if strings.EqualFold(p.ShortName, name) || strings.EqualFold(p.Name, name) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// buildNewIssueFields builds the customFields payload for CreateIssue from
+// a draft, taking each field's $type from the project's configuration and
+// checking values against its bundles.
+func buildNewIssueFields(fields []model.ProjectCustomField, parsed parsedIssue) ([]map[string]any, error) {
+	var customFields []map[string]any
+
+	for _, core := range []struct{ name, value string }{
+		{"State", parsed.state},
+		{"Type", parsed.issueType},
+	} {
+		if core.value == "" {
+			continue
+		}
+		f := findProjectField(fields, core.name)
+		if f == nil {
+			return nil, fmt.Errorf("project has no %s field", core.name)
+		}
+		cf, err := customFieldPayload(core.name, f.IssueFieldType(), f, []string{core.value})
+		if err != nil {
+			return nil, err
+		}
+		customFields = append(customFields, cf)
+	}
+
+	if parsed.assignee != "" {
+		fieldType := "SingleUserIssueCustomField"
+		if f := findProjectField(fields, "Assignee"); f != nil {
+			fieldType = f.IssueFieldType()
+		}
+		customFields = append(customFields, map[string]any{
+			"name":  "Assignee",
+			"$type": fieldType,
+			"value": map[string]any{
+				"login": parsed.assignee,
+				"$type": "User",
+			},
+		})
+	}
+
+	for _, name := range sortedKeys(parsed.fields) {
+		values := parsed.fields[name]
+		if coreFields[name] {
+			return nil, fmt.Errorf("set %s with its own key, not under fields", name)
+		}
+		f := findProjectField(fields, name)
+		if f == nil {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		if len(values) == 0 {
+			continue // left empty: use the project default
+		}
+		cf, err := customFieldPayload(name, f.IssueFieldType(), f, values)
+		if err != nil {
+			return nil, err
+		}
+		customFields = append(customFields, cf)
+	}
+
+	return customFields, nil
+}
+
+// customFieldPayload builds the customFields entry that sets a field to
+// values, or clears it when there are none. With the project's field
+// configuration, bundle values are checked and given their canonical names.
+func customFieldPayload(name, fieldType string, pf *model.ProjectCustomField, values []string) (map[string]any, error) {
+	kind := model.FieldKind(fieldType)
+	multi := strings.HasPrefix(fieldType, "Multi")
+	if pf != nil {
+		kind = pf.Kind()
+		multi = pf.Multi()
+	}
+	value, err := customFieldValue(kind, multi, pf, values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return map[string]any{
+		"name":  name,
+		"$type": fieldType,
+		"value": value,
+	}, nil
+}
+
+func customFieldValue(kind string, multi bool, pf *model.ProjectCustomField, values []string) (any, error) {
+	v, err := model.ParseFieldValue(kind, multi, values)
+	if errors.Is(err, model.ErrUnsupportedField) {
+		return nil, fmt.Errorf("this kind of field can't be set from the editor")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := v.(model.BundleField); ok && pf != nil && len(pf.Bundle.Values) > 0 {
+		for i, bv := range f.Values {
+			canonical := findBundleValue(pf.Bundle.Values, bv.Name)
+			if canonical == nil {
+				return nil, fmt.Errorf("unknown value %q (one of: %s)", bv.Name, bundleNames(pf.Bundle.Values))
+			}
+			f.Values[i] = *canonical
+		}
+	}
+	return v.Payload(), nil
+}
+
+func findProjectField(fields []model.ProjectCustomField, name string) *model.ProjectCustomField {
+	for i, f := range fields {
+		if f.Field.Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func findBundleValue(values []model.BundleValue, name string) *model.BundleValue {
+	for i, v := range values {
+		if strings.EqualFold(v.Name, name) {
+			return &values[i]
+		}
+	}
+	return nil
+}
+
+func bundleNames(values []model.BundleValue) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// nonEmpty returns s as a one-value list, or nil if it is empty.
+func nonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+// sameValues compares field values, ignoring case.
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
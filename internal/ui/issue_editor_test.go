@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cf/lazytrack/internal/api"
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func testProjectFields() []model.ProjectCustomField {
+	var state, issueType, assignee model.ProjectCustomField
+	state.Field.Name = "State"
+	state.Field.Type = "StateMachineIssueCustomField"
+	state.Bundle.Values = []model.BundleValue{{Name: "Open"}, {Name: "In Progress"}}
+	issueType.Field.Name = "Type"
+	issueType.Field.Type = "SingleEnumIssueCustomField"
+	issueType.Bundle.Values = []model.BundleValue{{Name: "Bug"}, {Name: "Task"}}
+	assignee.Field.Name = "Assignee"
+	assignee.Field.Type = "SingleUserIssueCustomField"
+	return []model.ProjectCustomField{state, issueType, assignee}
+}
+
+// createService serves projects, fields and tags and records created and
+// updated issues.
+type createService struct {
+	mockService
+	created   []string
+	fields    []map[string]any
+	updated   []map[string]any
+	tagged    []string
+	createErr error
+}
+
+func (s *createService) ListTags(ctx context.Context) ([]model.Tag, error) {
+	return []model.Tag{{ID: "t1", Name: "backend"}, {ID: "t2", Name: "ui"}}, nil
+}
+
+func (s *createService) AddIssueTag(ctx context.Context, issueID, tagID string) error {
+	s.tagged = append(s.tagged, "+"+issueID+" "+tagID)
+	return nil
+}
+
+func (s *createService) RemoveIssueTag(ctx context.Context, issueID, tagID string) error {
+	s.tagged = append(s.tagged, "-"+issueID+" "+tagID)
+	return nil
+}
+
+func (s *createService) UpdateIssue(ctx context.Context, issueID string, fields map[string]any) error {
+	s.updated = append(s.updated, fields)
+	return nil
+}
+
+func (s *createService) ListProjects(ctx context.Context) ([]model.Project, error) {
+	return []model.Project{{ID: "0-1", Name: "Project", ShortName: "PROJ"}}, nil
+}
+
+func (s *createService) ListProjectCustomFields(ctx context.Context, projectID string) ([]model.ProjectCustomField, error) {
+	return testProjectFields(), nil
+}
+
+func (s *createService) CreateIssue(ctx context.Context, projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+	s.created = append(s.created, projectID+" "+summary+" "+description)
+	s.fields = customFields
+	return &model.Issue{IDReadable: "PROJ-9"}, nil
+}
+
+func TestBuildNewIssueFields(t *testing.T) {
+	fields, err := buildNewIssueFields(testProjectFields(), parsedIssue{state: "in progress", issueType: "Bug", assignee: "jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 custom fields, got %d", len(fields))
+	}
+	if fields[0]["$type"] != "StateMachineIssueCustomField" {
+		t.Errorf("state $type = %v", fields[0]["$type"])
+	}
+	if v := fields[0]["value"].(map[string]string); v["name"] != "In Progress" {
+		t.Errorf("expected canonical state name, got %q", v["name"])
+	}
+	if fields[1]["$type"] != "SingleEnumIssueCustomField" {
+		t.Errorf("type $type = %v", fields[1]["$type"])
+	}
+
+	if fields, err := buildNewIssueFields(testProjectFields(), parsedIssue{}); err != nil || len(fields) != 0 {
+		t.Errorf("expected no fields for an empty draft, got %v, %v", fields, err)
+	}
+
+	_, err = buildNewIssueFields(testProjectFields(), parsedIssue{state: "Done"})
+	if err == nil || !strings.Contains(err.Error(), "Open, In Progress") {
+		t.Errorf("expected error listing allowed states, got %v", err)
+	}
+
+	_, err = buildNewIssueFields(testProjectFields(), parsedIssue{fields: map[string][]string{"Severity": {"High"}}})
+	if err == nil || !strings.Contains(err.Error(), `unknown field "Severity"`) {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestCreateFromEditor(t *testing.T) {
+	svc := &createService{}
+	app := NewApp(svc, config.DefaultState())
+	draft := "---\nproject: proj\nsummary: Crash\ntype: bug\n---\n\nSteps.\n"
+
+	parsed := parsedIssue{project: "proj", summary: "Crash", issueType: "bug", description: "Steps."}
+	if _, ok := app.createFromEditorCmd(parsed, draft)().(issueCreatedMsg); !ok {
+		t.Fatal("expected issueCreatedMsg")
+	}
+	if len(svc.created) != 1 || svc.created[0] != "0-1 Crash Steps." {
+		t.Errorf("unexpected creates: %v", svc.created)
+	}
+
+	parsed.issueType = "Epic"
+	msg, ok := app.createFromEditorCmd(parsed, draft)().(issueDraftRejectedMsg)
+	if !ok {
+		t.Fatal("expected the draft to be rejected")
+	}
+	if msg.content != draft || !strings.Contains(msg.problem, `Type: unknown value "Epic"`) {
+		t.Errorf("unexpected rejection: %+v", msg)
+	}
+
+	parsed.project = "NOPE"
+	msg, _ = app.createFromEditorCmd(parsed, draft)().(issueDraftRejectedMsg)
+	if !strings.Contains(msg.problem, "unknown project") {
+		t.Errorf("unexpected problem %q", msg.problem)
+	}
+}
+
+func TestCreateFromEditor_UnauthorizedGoesToSetup(t *testing.T) {
+	svc := &createService{createErr: fmt.Errorf("creating issue: %w", &api.Error{StatusCode: 401})}
+	app := NewApp(svc, config.DefaultState())
+
+	parsed := parsedIssue{project: "proj", summary: "Crash"}
+	msg := app.createFromEditorCmd(parsed, "draft")()
+	if _, ok := msg.(errMsg); !ok {
+		t.Fatalf("got %T, want errMsg for the auth flow", msg)
+	}
+	app.Update(msg)
+	if !app.authFailed {
+		t.Error("expected the auth failure flow")
+	}
+}
+
+func TestEditorFinished_Create(t *testing.T) {
+	app := NewApp(&createService{}, config.DefaultState())
+
+	path, err := writeDraftTempFile(nil, "\n  \n", "summary is required")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, cmd := app.Update(editorFinishedMsg{tempPath: path}); cmd != nil {
+		t.Error("expected an emptied draft to cancel")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected temp file to be removed")
+	}
+
+	path, _ = writeDraftTempFile(nil, mustTemplate(t, "PROJ")+"Details\n", "")
+	if _, cmd := app.Update(editorFinishedMsg{tempPath: path}); cmd == nil {
+		t.Error("expected the editor to re-open on a draft without summary")
+	}
+	if app.loading {
+		t.Error("expected no create request without a summary")
+	}
+
+	path, _ = writeDraftTempFile(nil, strings.Replace(mustTemplate(t, "PROJ"), "summary:", "summary: Crash", 1), "old problem")
+	_, cmd := app.Update(editorFinishedMsg{tempPath: path})
+	if cmd == nil || !app.loading {
+		t.Fatal("expected create request")
+	}
+	if _, ok := cmd().(issueCreatedMsg); !ok {
+		t.Error("expected issueCreatedMsg")
+	}
+}
+
+func TestCreateFromEditor_Tags(t *testing.T) {
+	svc := &createService{}
+	app := NewApp(svc, config.DefaultState())
+
+	parsed := parsedIssue{project: "PROJ", summary: "Crash", tags: []string{"UI"}}
+	if _, ok := app.createFromEditorCmd(parsed, "draft")().(issueCreatedMsg); !ok {
+		t.Fatal("expected issueCreatedMsg")
+	}
+	if strings.Join(svc.tagged, ",") != "+PROJ-9 t2" {
+		t.Errorf("unexpected tag changes: %v", svc.tagged)
+	}
+
+	parsed.tags = []string{"nope"}
+	msg, ok := app.createFromEditorCmd(parsed, "draft")().(issueDraftRejectedMsg)
+	if !ok || !strings.Contains(msg.problem, `unknown tag "nope"`) {
+		t.Errorf("expected unknown tag to be rejected, got %+v", msg)
+	}
+	if len(svc.created) != 1 {
+		t.Error("expected no issue created with an unknown tag")
+	}
+}
+
+func TestUpdateFromEditor(t *testing.T) {
+	svc := &createService{}
+	app := NewApp(svc, config.DefaultState())
+	original := &model.Issue{
+		IDReadable: "PROJ-1",
+		Summary:    "Crash",
+		Project:    &model.Project{ID: "0-1", ShortName: "PROJ"},
+		Tags:       []model.Tag{{ID: "t1", Name: "backend"}},
+		CustomFields: []model.CustomField{
+			makeCustomField("State", "StateMachineIssueCustomField", "Open"),
+		},
+	}
+
+	parsed := parsedIssue{summary: "Crash", state: "Open", tags: []string{"backend"}}
+	if _, ok := app.updateFromEditorCmd(original, parsed, "draft")().(issueUpdatedMsg); !ok {
+		t.Fatal("expected issueUpdatedMsg")
+	}
+	if len(svc.updated) != 0 || len(svc.tagged) != 0 {
+		t.Errorf("expected nothing to change, got %v %v", svc.updated, svc.tagged)
+	}
+
+	parsed = parsedIssue{summary: "Crash", state: "in progress", tags: []string{"ui"}}
+	if _, ok := app.updateFromEditorCmd(original, parsed, "draft")().(issueUpdatedMsg); !ok {
+		t.Fatal("expected issueUpdatedMsg")
+	}
+	if len(svc.updated) != 1 {
+		t.Fatalf("expected one update, got %v", svc.updated)
+	}
+	state := svc.updated[0]["customFields"].([]map[string]any)[0]
+	if state["value"].(map[string]string)["name"] != "In Progress" {
+		t.Errorf("expected canonical state name, got %v", state)
+	}
+	if strings.Join(svc.tagged, ",") != "+PROJ-1 t2,-PROJ-1 t1" {
+		t.Errorf("unexpected tag changes: %v", svc.tagged)
+	}
+
+	parsed.state = "Done"
+	msg, ok := app.updateFromEditorCmd(original, parsed, "draft")().(issueDraftRejectedMsg)
+	if !ok || msg.original != original || msg.content != "draft" {
+		t.Fatalf("expected the draft to be handed back, got %+v", msg)
+	}
+	if len(svc.updated) != 1 {
+		t.Error("expected no update for an invalid state")
+	}
+}
+
+func TestTagChanges(t *testing.T) {
+	current := []model.Tag{{ID: "t1", Name: "backend"}, {ID: "t2", Name: "ui"}}
+	if added, removed := tagChanges(current, nil); added != nil || removed != nil {
+		t.Error("expected a missing tags key to leave tags alone")
+	}
+	added, removed := tagChanges(current, []string{"UI", "docs", "docs"})
+	if strings.Join(added, ",") != "docs" || len(removed) != 1 || removed[0].ID != "t1" {
+		t.Errorf("tagChanges() = %v, %v", added, removed)
+	}
+}
+
+func TestIssueDraftCmd_ListsProjectFields(t *testing.T) {
+	app := NewApp(&createService{}, config.DefaultState())
+
+	msg, ok := app.issueDraftCmd(nil, "proj")().(issueDraftReadyMsg)
+	if !ok {
+		t.Fatal("expected a draft")
+	}
+	defer os.Remove(msg.tempPath)
+	if msg.original != nil {
+		t.Error("expected a new issue draft")
+	}
+	data, err := os.ReadFile(msg.tempPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"project: proj", "state: # one of: Open, In Progress", "type: # one of: Bug, Task"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("draft missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package ui
 
 import (
 	"fmt"
-	"os/exec"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -19,6 +19,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.issueDialog, cmd = a.issueDialog.Update(msg)
 		if a.issueDialog.submitted {
 			service := a.service
+			ctx := a.ctx
 			a.loading = true
 			customFields := a.issueDialog.buildCustomFields()
 			if a.issueDialog.mode == modeCreate {
@@ -28,11 +29,19 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 				summary := a.issueDialog.summaryInput.Value()
 				desc := a.issueDialog.descInput.Value()
+				parentID := a.issueDialog.parentID
+				linkTypes := a.linkTypes
 				return a, func() tea.Msg {
-					_, err := service.CreateIssue(projectID, summary, desc, customFields)
+					created, err := service.CreateIssue(ctx, projectID, summary, desc, customFields)
 					if err != nil {
 						return errMsg{err}
 					}
+					if parentID != "" && created != nil {
+						if err := linkSubtask(ctx, service, linkTypes, created.IDReadable, parentID); err != nil {
+							return issueCreatedMsg{notice: fmt.Sprintf("Created %s, but linking it as a subtask of %s failed: %s",
+								created.IDReadable, parentID, describeError(err))}
+						}
+					}
 					return issueCreatedMsg{}
 				}
 			} else {
@@ -45,7 +54,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						"description":  desc,
 						"customFields": customFields,
 					}
-					err := service.UpdateIssue(issueID, fields)
+					err := service.UpdateIssue(ctx, issueID, fields)
 					if err != nil {
 						return errMsg{err}
 					}
@@ -58,8 +67,9 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if len(a.issueDialog.projects) > 0 {
 				projectID := a.issueDialog.projects[a.issueDialog.projectIndex].ID
 				service := a.service
+				ctx := a.ctx
 				return a, tea.Batch(cmd, func() tea.Msg {
-					fields, err := service.ListProjectCustomFields(projectID)
+					fields, err := service.ListProjectCustomFields(ctx, projectID)
 					if err != nil {
 						return errMsg{err}
 					}
@@ -114,6 +124,103 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	// When saved searches are shown, route input to them
+	if a.savedSearches.active {
+		var cmd tea.Cmd
+		a.savedSearches, cmd = a.savedSearches.Update(msg)
+		if a.savedSearches.submitted {
+			return a, a.savedSearchCmd()
+		}
+		return a, cmd
+	}
+
+	// When sprint picker is active, route input to it
+	if a.sprintPicker.active {
+		var cmd tea.Cmd
+		a.sprintPicker, cmd = a.sprintPicker.Update(msg)
+		return a, tea.Batch(cmd, a.sprintPickerCmd())
+	}
+
+	// When tag dialog is active, route input to it
+	if a.tagDialog.active {
+		var cmd tea.Cmd
+		a.tagDialog, cmd = a.tagDialog.Update(msg)
+		if a.tagDialog.submitted {
+			d := &a.tagDialog
+			d.submitted = false
+			a.loading = true
+			return a, a.changeTagCmd(d.issueID, d.tag, d.current, d.action == tagRemove)
+		}
+		return a, cmd
+	}
+
+	// When work log dialog is active, route input to it
+	if a.workLogDialog.active {
+		var cmd tea.Cmd
+		a.workLogDialog, cmd = a.workLogDialog.Update(msg)
+		if a.workLogDialog.submitted {
+			a.workLogDialog.submitted = false
+			a.loading = true
+			return a, a.logWorkCmd(a.workLogDialog.issueID, a.workLogDialog.item, false)
+		}
+		return a, cmd
+	}
+
+	// When links dialog is active, route input to it
+	if a.linksDialog.active {
+		var cmd tea.Cmd
+		a.linksDialog, cmd = a.linksDialog.Update(msg)
+		if a.linksDialog.typesRequested {
+			a.linksDialog.typesRequested = false
+			if a.linkTypes != nil {
+				a.linksDialog.SetLinkTypes(a.linkTypes)
+				return a, cmd
+			}
+			service := a.service
+			ctx := a.ctx
+			return a, tea.Batch(cmd, func() tea.Msg {
+				types, err := service.ListLinkTypes(ctx)
+				if err != nil {
+					return errMsg{err}
+				}
+				return linkTypesLoadedMsg{types}
+			})
+		}
+		if a.linksDialog.submitted {
+			d := &a.linksDialog
+			d.submitted = false
+			a.loading = true
+			if d.action == linkJump {
+				a.listCollapsed = true
+				a.focus = detailPane
+				a.resizePanels()
+				return a, a.fetchDetailCmd(d.targetID)
+			}
+			return a, a.changeLinkCmd(d.issueID, d.linkID, d.targetID, d.action == linkRemove)
+		}
+		return a, cmd
+	}
+
+	// When attachment dialog is active, route input to it
+	if a.attachmentDialog.active {
+		var cmd tea.Cmd
+		a.attachmentDialog, cmd = a.attachmentDialog.Update(msg)
+		if a.attachmentDialog.submitted {
+			d := &a.attachmentDialog
+			d.submitted = false
+			a.loading = true
+			switch d.action {
+			case attachmentOpen:
+				return a, a.downloadAttachmentCmd(*d.selected, "", true)
+			case attachmentSave:
+				return a, a.downloadAttachmentCmd(*d.selected, d.path, false)
+			case attachmentUpload:
+				return a, a.uploadAttachmentCmd(d.issueID, d.path)
+			}
+		}
+		return a, cmd
+	}
+
 	// When going to issue, route input to goto field
 	if a.goingToIssue {
 		switch msg.String() {
@@ -141,9 +248,14 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// Dismiss error on any key
+	// Dismiss error on any key; enter on an auth failure re-runs setup
 	if a.err != "" {
+		if a.authFailed && msg.String() == "enter" {
+			a.rerunSetup = true
+			return a.quit()
+		}
 		a.err = ""
+		a.authFailed = false
 		return a, nil
 	}
 
@@ -153,20 +265,35 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// When the board is shown, route input to it
+	if a.board.active {
+		var cmd tea.Cmd
+		a.board, cmd = a.board.Update(msg)
+		return a, tea.Batch(cmd, a.boardCmd())
+	}
+
 	// Leader key dispatch: space was pressed last, now handle the action key
 	if a.leaderActive {
 		a.leaderActive = false
 		switch msg.String() {
 		case "c":
+			a.createParent = nil
+			if a.treeMode {
+				a.createParent = a.selected
+			}
 			a.loading = true
 			service := a.service
+			ctx := a.ctx
 			return a, func() tea.Msg {
-				projects, err := service.ListProjects()
+				projects, err := service.ListProjects(ctx)
 				if err != nil {
 					return errMsg{err}
 				}
 				return projectsLoadedMsg{projects}
 			}
+		case "C":
+			a.loading = true
+			return a, a.issueDraftCmd(nil, a.resolveGotoProject())
 		case "e":
 			if a.selected != nil {
 				issue := a.selected
@@ -175,8 +302,9 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if issue.Project != nil {
 					projectID := issue.Project.ID
 					service := a.service
+					ctx := a.ctx
 					return a, tea.Batch(cmd, func() tea.Msg {
-						fields, err := service.ListProjectCustomFields(projectID)
+						fields, err := service.ListProjectCustomFields(ctx, projectID)
 						if err != nil {
 							return errMsg{err}
 						}
@@ -193,9 +321,14 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "m":
 			if a.selected != nil {
 				a.commenting = true
+				a.editingComment = nil
 				a.commentInput.SetValue("")
 				return a, a.commentInput.Focus()
 			}
+		case "M":
+			if a.selected != nil {
+				return a, a.openCommentEditor(nil, "")
+			}
 		case "s":
 			if a.selected != nil {
 				a.settingState = true
@@ -211,8 +344,9 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "p":
 			a.loading = true
 			service := a.service
+			ctx := a.ctx
 			return a, func() tea.Msg {
-				projects, err := service.ListProjects()
+				projects, err := service.ListProjects(ctx)
 				if err != nil {
 					return errMsg{err}
 				}
@@ -227,17 +361,115 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			a.notifDialog.Open(a.lastCheckedMentions)
 			service := a.service
+			ctx := a.ctx
 			query := "mentioned: me sort by: updated desc"
 			if a.activeProject != nil {
 				query = "project: " + a.activeProject.ShortName + " " + query
 			}
 			return a, func() tea.Msg {
-				issues, err := service.ListIssues(query, 0, 50)
+				issues, err := service.ListIssues(ctx, query, 0, 50)
 				if err != nil {
 					return errMsg{err}
 				}
 				return mentionsLoadedMsg{issues}
 			}
+		case "F":
+			a.hideEmptyFields = !a.hideEmptyFields
+			a.reRenderContent()
+			if a.hideEmptyFields {
+				a.notice = "Hiding empty fields"
+			} else {
+				a.notice = "Showing empty fields"
+			}
+			return a, nil
+		case "h":
+			a.treeMode = !a.treeMode
+			a.refreshListItems()
+			return a, nil
+		case "l":
+			if a.selected != nil {
+				a.linksDialog.Open(a.selected)
+				return a, nil
+			}
+		case "g":
+			if a.selected != nil {
+				d := &a.tagDialog
+				cmd := d.Open(a.selected, a.tags)
+				if !d.tagsRequested {
+					return a, cmd
+				}
+				d.tagsRequested = false
+				service := a.service
+				ctx := a.ctx
+				return a, tea.Batch(cmd, func() tea.Msg {
+					tags, err := service.ListTags(ctx)
+					if err != nil {
+						return errMsg{err}
+					}
+					return tagsLoadedMsg{tags}
+				})
+			}
+		case "T":
+			return a, a.toggleTimer()
+		case "H":
+			return a, a.toggleHistory()
+		case "W":
+			return a, a.toggleWorkItems()
+		case "b":
+			a.loading = true
+			service := a.service
+			ctx := a.ctx
+			return a, func() tea.Msg {
+				agiles, err := service.ListAgiles(ctx)
+				if err != nil {
+					return errMsg{err}
+				}
+				return agilesLoadedMsg{agiles}
+			}
+		case "/":
+			return a, a.openSavedSearches()
+		case "S":
+			a.loading = true
+			service := a.service
+			ctx := a.ctx
+			return a, func() tea.Msg {
+				agiles, err := service.ListAgiles(ctx)
+				if err != nil {
+					return errMsg{err}
+				}
+				return sprintBoardsLoadedMsg{agiles}
+			}
+		case "w":
+			if a.selected != nil {
+				d := &a.workLogDialog
+				projectID := ""
+				if a.selected.Project != nil {
+					projectID = a.selected.Project.ID
+				}
+				cmd := d.Open(a.selected, a.workItemTypes[projectID])
+				if !d.typesRequested {
+					return a, cmd
+				}
+				d.typesRequested = false
+				service := a.service
+				ctx := a.ctx
+				return a, tea.Batch(cmd, func() tea.Msg {
+					types, err := service.ListWorkItemTypes(ctx, projectID)
+					if err != nil {
+						return errMsg{err}
+					}
+					return workItemTypesLoadedMsg{projectID: projectID, types: types}
+				})
+			}
+		case "o":
+			if a.selected != nil {
+				a.attachmentDialog.Open(a.selected.IDReadable, a.selected.Attachments)
+				return a, nil
+			}
+		case "u":
+			if a.selected != nil {
+				return a, a.attachmentDialog.OpenUpload(a.selected.IDReadable)
+			}
 		case "t":
 			a.listCollapsed = !a.listCollapsed
 			if a.listCollapsed {
@@ -250,17 +482,8 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		case "v":
 			if a.selected != nil {
-				issue := a.selected
-				tempPath, err := writeIssueTempFile(issue)
-				if err != nil {
-					a.err = "Failed to create temp file: " + err.Error()
-					return a, nil
-				}
-				editor := resolveEditor()
-				c := exec.Command(editor, tempPath)
-				return a, tea.ExecProcess(c, func(err error) tea.Msg {
-					return editorFinishedMsg{err: err, tempPath: tempPath, original: issue}
-				})
+				a.loading = true
+				return a, a.issueDraftCmd(a.selected, "")
 			}
 		}
 		// Unrecognized key — just dismiss leader mode
@@ -275,9 +498,10 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if a.selected != nil {
 				issueID := a.selected.IDReadable
 				service := a.service
+				ctx := a.ctx
 				a.loading = true
 				return a, func() tea.Msg {
-					err := service.DeleteIssue(issueID)
+					err := service.DeleteIssue(ctx, issueID)
 					if err != nil {
 						return errMsg{err}
 					}
@@ -290,35 +514,48 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// When confirming comment delete, handle y/n
+	if a.confirmCommentDelete {
+		a.confirmCommentDelete = false
+		if c := a.comments.Selected(); (msg.String() == "y" || msg.String() == "Y") && c != nil {
+			a.loading = true
+			return a, a.deleteCommentCmd(a.selected.IDReadable, c.ID)
+		}
+		return a, nil
+	}
+
 	// When commenting, route input to comment textarea
 	if a.commenting {
+		if a.mentions.HandleKey(msg, &a.commentInput) {
+			return a, nil
+		}
 		switch msg.String() {
 		case "esc":
 			a.commenting = false
+			a.editingComment = nil
 			a.commentInput.Blur()
+			a.mentions.Reset()
 			return a, nil
 		case "ctrl+s":
 			text := a.commentInput.Value()
 			if text != "" && a.selected != nil {
 				issueID := a.selected.IDReadable
-				service := a.service
 				a.commenting = false
+				a.mentions.Reset()
 				a.commentInput.Blur()
 				a.commentInput.SetValue("")
 				a.loading = true
-				return a, func() tea.Msg {
-					_, err := service.AddComment(issueID, text)
-					if err != nil {
-						return errMsg{err}
-					}
-					return commentAddedMsg{}
+				if c := a.editingComment; c != nil {
+					a.editingComment = nil
+					return a, a.updateCommentCmd(issueID, c.ID, text)
 				}
+				return a, a.addCommentCmd(issueID, text)
 			}
 			return a, nil
 		default:
 			var cmd tea.Cmd
 			a.commentInput, cmd = a.commentInput.Update(msg)
-			return a, cmd
+			return a, tea.Batch(cmd, a.mentions.Refresh(a.commentInput))
 		}
 	}
 
@@ -331,6 +568,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				issueID := a.selected.IDReadable
 				stateFieldType := a.selected.StateFieldType()
 				service := a.service
+				ctx := a.ctx
 				a.settingState = false
 				a.stateInput.Blur()
 				a.loading = true
@@ -347,7 +585,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 							},
 						},
 					}
-					err := service.UpdateIssue(issueID, fields)
+					err := service.UpdateIssue(ctx, issueID, fields)
 					if err != nil {
 						return errMsg{err}
 					}
@@ -374,6 +612,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if login != "" && a.selected != nil {
 				issueID := a.selected.IDReadable
 				service := a.service
+				ctx := a.ctx
 				a.assigning = false
 				a.assignInput.Blur()
 				a.loading = true
@@ -390,7 +629,7 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 							},
 						},
 					}
-					err := service.UpdateIssue(issueID, fields)
+					err := service.UpdateIssue(ctx, issueID, fields)
 					if err != nil {
 						return errMsg{err}
 					}
@@ -411,53 +650,72 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// When searching, route all input to the search field
 	if a.searching {
+		h := &a.queryHistory
+		if h.recall {
+			return a.handleHistoryRecall(msg)
+		}
+		// Shown completions take ctrl+n/ctrl+p, tab and esc
+		if a.queryAssist.HandleKey(msg, &a.searchInput) {
+			return a, a.queryAssist.Refresh(a.searchInput)
+		}
 		switch msg.String() {
 		case "enter":
 			a.query = a.searchInput.Value()
+			h.Add(a.query)
 			a.searching = false
 			a.searchInput.Blur()
+			h.Reset()
+			a.queryAssist.Reset()
 			a.loading = true
 			return a, a.fetchIssuesCmd()
 		case "esc":
 			a.searching = false
 			a.searchInput.Blur()
+			h.Reset()
+			a.queryAssist.Reset()
+			return a, nil
+		case "up", "ctrl+p":
+			if query, ok := h.Older(a.searchInput.Value()); ok {
+				a.searchInput.SetValue(query)
+				a.searchInput.CursorEnd()
+				a.queryAssist.Reset()
+			}
+			return a, nil
+		case "down", "ctrl+n":
+			if query, ok := h.Newer(); ok {
+				a.searchInput.SetValue(query)
+				a.searchInput.CursorEnd()
+				a.queryAssist.Reset()
+			}
 			return a, nil
+		case "ctrl+r":
+			a.searchInput.Blur()
+			a.queryAssist.Reset()
+			return a, h.StartRecall(a.searchInput.Value())
 		default:
 			var cmd tea.Cmd
 			a.searchInput, cmd = a.searchInput.Update(msg)
-			return a, cmd
+			return a, tea.Batch(cmd, a.queryAssist.Refresh(a.searchInput))
 		}
 	}
 
 	switch msg.String() {
 	case "ctrl+c", "q":
-		state := config.State{
-			UI: config.UIState{
-				ListRatio:           a.listRatio,
-				ListCollapsed:       a.listCollapsed,
-				LastCheckedMentions: a.lastCheckedMentions,
-			},
-		}
-		if a.selected != nil {
-			state.UI.SelectedIssue = a.selected.IDReadable
-		}
-		if a.activeProject != nil {
-			state.UI.ActiveProject = a.activeProject.ShortName
-		}
-		_ = config.SaveStateToPath(a.statePath, state)
-		return a, tea.Quit
+		return a.quit()
 	case "tab":
 		hasComments := a.selected != nil && len(a.selected.Comments) > 0
 		switch a.focus {
 		case listPane:
 			a.focus = detailPane
 		case detailPane:
-			if hasComments {
+			if a.showHistory && a.selected != nil {
+				a.focus = historyPane
+			} else if hasComments {
 				a.focus = commentsPane
 			} else {
 				a.focus = listPane
 			}
-		case commentsPane:
+		case commentsPane, historyPane:
 			a.focus = listPane
 		}
 		return a, nil
@@ -471,6 +729,8 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "/":
 		a.searching = true
+		a.queryHistory.Reset()
+		a.queryAssist.Reset()
 		a.searchInput.SetValue(a.query)
 		return a, a.searchInput.Focus()
 	case " ":
@@ -510,6 +770,51 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.gotoInput.SetValue("")
 		a.gotoInput.Prompt = fmt.Sprintf("Go to %s-#: ", proj)
 		return a, a.gotoInput.Focus()
+	case "z":
+		if a.focus == listPane && a.treeMode {
+			a.toggleFold()
+			return a, nil
+		}
+	case "e":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			a.commenting = true
+			a.editingComment = c
+			a.commentInput.SetValue(c.Text)
+			return a, a.commentInput.Focus()
+		}
+	case "E":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			return a, a.openCommentEditor(c, c.Text)
+		}
+	case "a":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			a.commenting = true
+			a.editingComment = nil
+			a.commentInput.SetValue(quoteReply(c))
+			return a, a.commentInput.Focus()
+		}
+	case "A":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			return a, a.openCommentEditor(nil, quoteReply(c))
+		}
+	case "x":
+		if a.focus == commentsPane && a.comments.Selected() != nil {
+			a.confirmCommentDelete = true
+			return a, nil
+		}
+	case "y":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			copyToClipboard(c.Text)
+			a.notice = "Copied comment by " + commentAuthor(c)
+			return a, nil
+		}
+	case "o":
+		if c := a.comments.Selected(); a.focus == commentsPane && c != nil {
+			if err := startOpener(a.service.IssueURL(a.selected.IDReadable, c.ID)); err != nil {
+				a.err = "Failed to open browser: " + err.Error()
+			}
+			return a, nil
+		}
 	case "r":
 		a.loading = true
 		if a.selected != nil {
@@ -543,3 +848,122 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// to focus-based panel routing (j/k, arrows, pgup/pgdn, etc.)
 	return nil, nil
 }
+
+// saveState persists layout, selection and project context for the next launch.
+func (a *App) saveState() {
+	state := config.State{
+		UI: config.UIState{
+			ListRatio:           a.listRatio,
+			ListCollapsed:       a.listCollapsed,
+			LastCheckedMentions: a.lastCheckedMentions,
+			TreeMode:            a.treeMode,
+			HideEmptyFields:     a.hideEmptyFields,
+			SearchesViewed:      a.searchesViewed,
+		},
+		Timer:        a.timer,
+		QueryHistory: a.queryHistory.entries,
+	}
+	if a.selected != nil {
+		state.UI.SelectedIssue = a.selected.IDReadable
+	}
+	if a.activeProject != nil {
+		state.UI.ActiveProject = a.activeProject.ShortName
+	}
+	_ = config.SaveStateToPath(a.statePath, state)
+}
+
+// handleHistoryRecall handles keys during ctrl+r recall in the search
+// prompt: enter runs the recalled query, tab puts it in the prompt to edit
+// and esc goes back to what was typed before.
+func (a *App) handleHistoryRecall(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	h := &a.queryHistory
+	switch msg.String() {
+	case "enter":
+		match := h.Match()
+		if match == "" {
+			return a, nil
+		}
+		h.Reset()
+		a.searchInput.SetValue(match)
+		a.query = match
+		h.Add(match)
+		a.searching = false
+		a.loading = true
+		return a, a.fetchIssuesCmd()
+	case "tab", "right":
+		if match := h.Match(); match != "" {
+			a.searchInput.SetValue(match)
+			a.searchInput.CursorEnd()
+		}
+		h.Reset()
+		return a, a.searchInput.Focus()
+	case "esc", "ctrl+g":
+		a.searchInput.SetValue(h.draft)
+		h.Reset()
+		return a, a.searchInput.Focus()
+	}
+	return a, h.UpdateRecall(msg)
+}
+
+// quit saves state, cancels every in-flight request, removes the temp copies
+// of opened attachments and exits the program.
+func (a *App) quit() (tea.Model, tea.Cmd) {
+	a.saveState()
+	a.cancel()
+	for _, path := range a.openedFiles {
+		os.Remove(path)
+	}
+	return a, tea.Quit
+}
+
+// toggleWorkItems shows or hides the work items section of the detail view.
+// Work items are loaded when the section is opened and with every detail
+// fetch while it stays open.
+func (a *App) toggleWorkItems() tea.Cmd {
+	a.showWork = !a.showWork
+	a.workItems = nil
+	if a.showWork {
+		a.notice = "Showing work items"
+	} else {
+		a.notice = "Hiding work items"
+	}
+	if a.selected == nil {
+		return nil
+	}
+	a.detail.SetContent(a.renderDetail())
+	if !a.showWork {
+		return nil
+	}
+	return a.fetchWorkItemsCmd(a.selected.IDReadable)
+}
+
+// toggleHistory swaps the comments column for the issue's activity feed and
+// back. The feed is loaded each time it is shown.
+func (a *App) toggleHistory() tea.Cmd {
+	a.showHistory = !a.showHistory
+	if !a.showHistory {
+		if a.historyCancel != nil {
+			a.historyCancel()
+		}
+		a.activities = nil
+		if a.focus == historyPane {
+			a.focus = detailPane
+			if a.selected != nil && len(a.selected.Comments) > 0 {
+				a.focus = commentsPane
+			}
+		}
+		a.resizePanels()
+		a.reRenderContent()
+		return nil
+	}
+
+	if a.focus == commentsPane {
+		a.focus = historyPane
+	}
+	a.resizePanels()
+	a.reRenderContent()
+	if a.selected == nil {
+		return nil
+	}
+	return a.fetchHistoryCmd(a.selected.IDReadable)
+}
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type linksMode int
+
+const (
+	linksListMode linksMode = iota
+	linksTypeMode
+	linksTargetMode
+)
+
+type linkAction int
+
+const (
+	linkJump linkAction = iota
+	linkAdd
+	linkRemove
+)
+
+// linkRow is one linked issue as shown in the dialog.
+type linkRow struct {
+	linkID string
+	phrase string
+	issue  model.Issue
+}
+
+// flattenLinks returns one row per linked issue, in server order.
+func flattenLinks(links []model.IssueLink) []linkRow {
+	var rows []linkRow
+	for _, l := range links {
+		for _, issue := range l.Issues {
+			rows = append(rows, linkRow{linkID: l.ID, phrase: l.Phrase(), issue: issue})
+		}
+	}
+	return rows
+}
+
+// LinksDialog is a centered popup listing an issue's links. From it the
+// user can jump to a linked issue, add a link of any type or remove one.
+type LinksDialog struct {
+	issueID        string
+	rows           []linkRow
+	cursor         int
+	mode           linksMode
+	confirming     bool // waiting for y/n to remove the row under the cursor
+	directions     []model.LinkDirection
+	typeCursor     int
+	typesRequested bool // app should load link types and call SetLinkTypes
+	loadingTypes   bool
+	targetInput    textinput.Model
+	active         bool
+	submitted      bool
+	action         linkAction
+	linkID         string // link type and direction to add or remove
+	targetID       string // issue to jump to, link or unlink
+	err            string
+}
+
+func NewLinksDialog() LinksDialog {
+	ti := textinput.New()
+	ti.Placeholder = "PROJ-123"
+	ti.Prompt = "Issue: "
+	ti.CharLimit = 64
+	return LinksDialog{targetInput: ti}
+}
+
+// Open activates the dialog for the issue's current links. The issue's
+// project prefix (e.g. "PROJ-") pre-fills the target input for new links.
+func (d *LinksDialog) Open(issue *model.Issue) {
+	d.issueID = issue.IDReadable
+	d.rows = flattenLinks(issue.Links)
+	d.cursor = 0
+	d.mode = linksListMode
+	d.confirming = false
+	d.typesRequested = false
+	d.loadingTypes = false
+	d.active = true
+	d.submitted = false
+	d.err = ""
+	d.targetInput.Blur()
+	d.targetInput.SetValue("")
+	if issue.Project != nil && issue.Project.ShortName != "" {
+		d.targetInput.SetValue(issue.Project.ShortName + "-")
+	}
+}
+
+func (d *LinksDialog) Close() {
+	d.active = false
+	d.targetInput.Blur()
+}
+
+// SetLinkTypes provides the link types the server defines.
+func (d *LinksDialog) SetLinkTypes(types []model.IssueLinkType) {
+	d.loadingTypes = false
+	d.directions = nil
+	for _, t := range types {
+		d.directions = append(d.directions, t.Directions()...)
+	}
+	d.typeCursor = 0
+}
+
+// SetError shows err inside the dialog.
+func (d *LinksDialog) SetError(err string) {
+	d.loadingTypes = false
+	d.err = err
+}
+
+func (d *LinksDialog) Update(msg tea.Msg) (LinksDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+	key := keyMsg.String()
+
+	switch d.mode {
+	case linksTypeMode:
+		switch key {
+		case "esc":
+			d.mode = linksListMode
+		case "up", "k":
+			if d.typeCursor > 0 {
+				d.typeCursor--
+			}
+		case "down", "j":
+			if d.typeCursor < len(d.directions)-1 {
+				d.typeCursor++
+			}
+		case "enter":
+			if len(d.directions) > 0 {
+				d.mode = linksTargetMode
+				d.targetInput.CursorEnd()
+				return *d, d.targetInput.Focus()
+			}
+		}
+		return *d, nil
+
+	case linksTargetMode:
+		switch key {
+		case "esc":
+			d.mode = linksTypeMode
+			d.targetInput.Blur()
+			return *d, nil
+		case "enter":
+			target := strings.TrimSpace(d.targetInput.Value())
+			if target == "" || strings.HasSuffix(target, "-") {
+				d.err = "Issue ID is required"
+				return *d, nil
+			}
+			d.action = linkAdd
+			d.linkID = d.directions[d.typeCursor].LinkID
+			d.targetID = target
+			d.submitted = true
+			d.Close()
+			return *d, nil
+		}
+		var cmd tea.Cmd
+		d.targetInput, cmd = d.targetInput.Update(msg)
+		return *d, cmd
+	}
+
+	if d.confirming {
+		d.confirming = false
+		if key == "y" || key == "Y" {
+			row := d.rows[d.cursor]
+			d.action = linkRemove
+			d.linkID = row.linkID
+			d.targetID = row.issue.IDReadable
+			d.submitted = true
+			d.Close()
+		}
+		return *d, nil
+	}
+
+	d.err = ""
+	switch key {
+	case "esc":
+		d.Close()
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.rows)-1 {
+			d.cursor++
+		}
+	case "enter":
+		if len(d.rows) > 0 {
+			d.action = linkJump
+			d.targetID = d.rows[d.cursor].issue.IDReadable
+			d.submitted = true
+			d.Close()
+		}
+	case "a":
+		d.mode = linksTypeMode
+		if d.directions == nil {
+			d.typesRequested = true
+			d.loadingTypes = true
+		}
+	case "d", "x":
+		if len(d.rows) > 0 {
+			d.confirming = true
+		}
+	}
+	return *d, nil
+}
+
+func (d *LinksDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width * 3 / 5
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	contentWidth := dialogWidth - 6
+
+	normalStyle := lipgloss.NewStyle().Width(contentWidth)
+	selectedStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Background(lipgloss.Color("237")).
+		Foreground(lipgloss.Color("255"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var b strings.Builder
+	var hint string
+
+	switch d.mode {
+	case linksListMode:
+		b.WriteString(titleStyle.Render("Links — "+d.issueID) + "\n\n")
+		if len(d.rows) == 0 {
+			b.WriteString(dim.Render("No links") + "\n")
+		}
+		for i, row := range d.rows {
+			line := fmt.Sprintf("%-18s %-12s %s", row.phrase, row.issue.IDReadable, row.issue.Summary)
+			if lipgloss.Width(line) > contentWidth {
+				line = ansiTruncate(line, contentWidth-1) + "…"
+			}
+			if i == d.cursor {
+				b.WriteString(selectedStyle.Render(line) + "\n")
+			} else {
+				b.WriteString(normalStyle.Render(line) + "\n")
+			}
+		}
+		hint = "j/k: navigate  enter: jump  a: add  d: remove  esc: close"
+		if d.confirming {
+			row := d.rows[d.cursor]
+			b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Remove link %s %s? (y/n)", row.phrase, row.issue.IDReadable)))
+			hint = ""
+		}
+
+	case linksTypeMode:
+		b.WriteString(titleStyle.Render("Add link from "+d.issueID) + "\n\n")
+		switch {
+		case d.loadingTypes:
+			b.WriteString(dim.Render("Loading link types...") + "\n")
+		case len(d.directions) == 0 && d.err == "":
+			b.WriteString(dim.Render("No link types defined") + "\n")
+		}
+		for i, dir := range d.directions {
+			if i == d.typeCursor {
+				b.WriteString(selectedStyle.Render(dir.Phrase) + "\n")
+			} else {
+				b.WriteString(normalStyle.Render(dir.Phrase) + "\n")
+			}
+		}
+		hint = "j/k: navigate  enter: select  esc: back"
+
+	case linksTargetMode:
+		phrase := d.directions[d.typeCursor].Phrase
+		b.WriteString(titleStyle.Render(d.issueID+" "+phrase) + "\n\n")
+		b.WriteString(d.targetInput.View() + "\n")
+		hint = "enter: link  esc: back"
+	}
+
+	if d.err != "" {
+		b.WriteString("\n" + errorStyle.Render("Error: "+d.err) + "\n")
+	}
+	if hint != "" {
+		b.WriteString("\n" + dim.Render(hint))
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
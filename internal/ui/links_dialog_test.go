@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// linkService records link changes and serves a fixed set of link types.
+type linkService struct {
+	mockService
+	typeCalls int
+	added     []string
+	removed   []string
+}
+
+func (s *linkService) ListLinkTypes(ctx context.Context) ([]model.IssueLinkType, error) {
+	s.typeCalls++
+	return []model.IssueLinkType{
+		{ID: "74-0", Name: "Depend", SourceToTarget: "depends on", TargetToSource: "is required for", Directed: true},
+		{ID: "74-1", Name: "Relates", SourceToTarget: "relates to"},
+	}, nil
+}
+
+func (s *linkService) AddIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	s.added = append(s.added, issueID+" "+linkID+" "+targetID)
+	return nil
+}
+
+func (s *linkService) RemoveIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	s.removed = append(s.removed, issueID+" "+linkID+" "+targetID)
+	return nil
+}
+
+func linkedIssue() *model.Issue {
+	depend := &model.IssueLinkType{ID: "74-0", SourceToTarget: "depends on", TargetToSource: "is required for", Directed: true}
+	return &model.Issue{
+		IDReadable: "PROJ-1",
+		Project:    &model.Project{ShortName: "PROJ"},
+		Links: []model.IssueLink{
+			{ID: "74-0s", Direction: "OUTWARD", LinkType: depend, Issues: []model.Issue{{IDReadable: "PROJ-9", Summary: "Schema"}}},
+			{ID: "74-0t", Direction: "INWARD", LinkType: depend, Issues: []model.Issue{{IDReadable: "PROJ-3", Summary: "Release"}}},
+		},
+	}
+}
+
+func newLinksTestApp(svc IssueService) *App {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+	app.selected = linkedIssue()
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	return app
+}
+
+func TestFlattenLinks(t *testing.T) {
+	rows := flattenLinks(linkedIssue().Links)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[1].phrase != "is required for" || rows[1].linkID != "74-0t" || rows[1].issue.IDReadable != "PROJ-3" {
+		t.Errorf("unexpected row: %+v", rows[1])
+	}
+}
+
+func TestLinks_JumpToLinkedIssue(t *testing.T) {
+	app := newLinksTestApp(&linkService{})
+	if !app.linksDialog.active {
+		t.Fatal("expected links dialog after space l")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatal("expected detail fetch cmd")
+	}
+	if app.pendingDetailID != "PROJ-3" {
+		t.Errorf("got pending detail %q, want PROJ-3", app.pendingDetailID)
+	}
+	if app.focus != detailPane {
+		t.Error("expected focus on detail pane after jump")
+	}
+}
+
+func TestLinks_AddLoadsTypesOnce(t *testing.T) {
+	svc := &linkService{}
+	app := newLinksTestApp(svc)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if cmd == nil {
+		t.Fatal("expected link type fetch")
+	}
+	app.Update(cmd())
+	if len(app.linksDialog.directions) != 3 {
+		t.Fatalf("got %d directions, want 3", len(app.linksDialog.directions))
+	}
+
+	// pick "is required for", then enter the target number after the prefilled "PROJ-"
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected add link cmd")
+	}
+	if _, ok := cmd().(linksChangedMsg); !ok {
+		t.Fatal("expected linksChangedMsg")
+	}
+	if len(svc.added) != 1 || svc.added[0] != "PROJ-1 74-0t PROJ-7" {
+		t.Errorf("unexpected adds: %v", svc.added)
+	}
+
+	// A second add uses the cached types
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if svc.typeCalls != 1 {
+		t.Errorf("got %d link type fetches, want 1", svc.typeCalls)
+	}
+	if len(app.linksDialog.directions) != 3 {
+		t.Error("expected cached link types in reopened dialog")
+	}
+}
+
+func TestLinks_RemoveNeedsConfirmation(t *testing.T) {
+	svc := &linkService{}
+	app := newLinksTestApp(svc)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !app.linksDialog.active || app.linksDialog.confirming {
+		t.Fatal("expected n to cancel removal and keep the dialog open")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected remove link cmd")
+	}
+	cmd()
+	if len(svc.removed) != 1 || svc.removed[0] != "PROJ-1 74-0s PROJ-9" {
+		t.Errorf("unexpected removals: %v", svc.removed)
+	}
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// MentionPicker suggests users while an @mention is typed in a textarea.
+// After each edit the owner calls Refresh; when the query changes a
+// debounced search is scheduled, guarded by a generation counter like the
+// assignee autocomplete.
+type MentionPicker struct {
+	active    bool   // the cursor is right after an @mention
+	query     string // partial login typed after "@"
+	results   []model.User
+	cursor    int
+	gen       int
+	dismissed bool // esc hid the suggestions for the current query
+}
+
+// Reset hides the picker and drops any pending search.
+func (p *MentionPicker) Reset() {
+	p.active = false
+	p.query = ""
+	p.results = nil
+	p.cursor = 0
+	p.dismissed = false
+	p.gen++
+}
+
+// Refresh looks for an @mention before the textarea cursor. Returns a
+// debounced search command when the mention query changed.
+func (p *MentionPicker) Refresh(ta textarea.Model) tea.Cmd {
+	query, ok := mentionQuery(ta)
+	if !ok {
+		if p.active {
+			p.Reset()
+		}
+		return nil
+	}
+	if p.active && query == p.query {
+		return nil
+	}
+	p.active = true
+	p.query = query
+	p.dismissed = false
+	p.gen++
+	gen := p.gen
+	return tea.Tick(300*time.Millisecond, func(t time.Time) tea.Msg {
+		return mentionDebounceMsg{generation: gen}
+	})
+}
+
+// SetResults handles search results with generation guard.
+func (p *MentionPicker) SetResults(users []model.User, gen int) {
+	if gen != p.gen || !p.active {
+		return
+	}
+	p.results = users
+	p.cursor = 0
+}
+
+func (p *MentionPicker) visible() bool {
+	return p.active && !p.dismissed && len(p.results) > 0
+}
+
+// HandleKey navigates and accepts suggestions while they are shown.
+// Returns false when the key should go to the textarea instead.
+func (p *MentionPicker) HandleKey(msg tea.KeyMsg, ta *textarea.Model) bool {
+	if !p.visible() {
+		return false
+	}
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "ctrl+n":
+		if p.cursor < len(p.results)-1 {
+			p.cursor++
+		}
+	case "enter", "tab":
+		p.complete(ta)
+	case "esc":
+		p.dismissed = true
+	default:
+		return false
+	}
+	return true
+}
+
+// complete replaces the partial login with the selected user's login.
+func (p *MentionPicker) complete(ta *textarea.Model) {
+	user := p.results[p.cursor]
+	for range []rune(p.query) {
+		*ta, _ = ta.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	ta.InsertString(user.Login + " ")
+	p.Reset()
+}
+
+// View renders the suggestions, or "" when there are none to show.
+func (p *MentionPicker) View(width int) string {
+	if !p.visible() {
+		return ""
+	}
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("237")).Foreground(lipgloss.Color("255"))
+	var b strings.Builder
+	for i, u := range p.results {
+		line := fmt.Sprintf("@%s (%s)", u.Login, u.FullName)
+		if lipgloss.Width(line) > width && width > 1 {
+			line = ansiTruncate(line, width-1) + "…"
+		}
+		if i == p.cursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// mentionPicker returns the picker of the textarea being typed in, or nil.
+func (a *App) mentionPicker() *MentionPicker {
+	switch {
+	case a.commenting:
+		return &a.mentions
+	case a.issueDialog.active && a.issueDialog.focusIndex == fieldDescription:
+		return &a.issueDialog.mentions
+	}
+	return nil
+}
+
+// mentionQuery returns the partial login between an "@" and the textarea
+// cursor. The "@" must start a word, so email addresses don't count.
+func mentionQuery(ta textarea.Model) (string, bool) {
+	lines := strings.Split(ta.Value(), "\n")
+	if ta.Line() >= len(lines) {
+		return "", false
+	}
+	line := []rune(lines[ta.Line()])
+	info := ta.LineInfo()
+	col := min(info.StartColumn+info.ColumnOffset, len(line))
+	before := line[:col]
+
+	at := -1
+	for i := len(before) - 1; i >= 0; i-- {
+		if before[i] == '@' {
+			at = i
+			break
+		}
+		if !isLoginRune(before[i]) {
+			return "", false
+		}
+	}
+	if at < 0 {
+		return "", false
+	}
+	if at > 0 && !unicode.IsSpace(before[at-1]) && !strings.ContainsRune("([{\"'>", before[at-1]) {
+		return "", false
+	}
+	return string(before[at+1:]), true
+}
+
+func isLoginRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._-", r)
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// mentionService answers user searches and records the queries.
+type mentionService struct {
+	mockService
+	queries []string
+}
+
+func (s *mentionService) SearchUsers(ctx context.Context, query string) ([]model.User, error) {
+	s.queries = append(s.queries, query)
+	return []model.User{
+		{Login: "alice", FullName: "Alice A"},
+		{Login: "alicia", FullName: "Alicia B"},
+	}, nil
+}
+
+func TestMentionQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		ok    bool
+	}{
+		{"bare at", "hi @", "", true},
+		{"partial login", "hi @al", "al", true},
+		{"login with dots", "@john.doe", "john.doe", true},
+		{"second line", "first\n(@bo", "bo", true},
+		{"after space", "@al ", "", false},
+		{"email", "mail me@example", "", false},
+		{"no at", "hello", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta := textarea.New()
+			ta.SetWidth(40)
+			ta.SetValue(tt.text)
+			query, ok := mentionQuery(ta)
+			if query != tt.query || ok != tt.ok {
+				t.Errorf("mentionQuery(%q) = %q, %v; want %q, %v", tt.text, query, ok, tt.query, tt.ok)
+			}
+		})
+	}
+}
+
+// runMentionSearch delivers the debounced search for the picker's current
+// generation and its results.
+func runMentionSearch(t *testing.T, app *App) {
+	t.Helper()
+	p := app.mentionPicker()
+	if p == nil {
+		t.Fatal("expected an active mention picker")
+	}
+	_, cmd := app.Update(mentionDebounceMsg{generation: p.gen})
+	if cmd == nil {
+		t.Fatal("expected user search cmd")
+	}
+	app.Update(cmd())
+}
+
+func TestMention_CompleteInCommentTextarea(t *testing.T) {
+	svc := &mentionService{}
+	app := newCommentsTestApp(svc)
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if !app.commenting {
+		t.Fatal("expected comment textarea to open")
+	}
+
+	typeRunes(app, "thanks @al")
+	runMentionSearch(t, app)
+	if len(svc.queries) != 1 || svc.queries[0] != "al" {
+		t.Fatalf("unexpected searches: %v", svc.queries)
+	}
+	if !strings.Contains(app.View(), "@alicia (Alicia B)") {
+		t.Error("expected suggestions below the textarea")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := app.commentInput.Value(); got != "thanks @alicia " {
+		t.Errorf("expected completed mention, got %q", got)
+	}
+	if app.mentions.visible() {
+		t.Error("expected suggestions hidden after completion")
+	}
+	if !app.commenting {
+		t.Error("expected to keep commenting")
+	}
+}
+
+func TestMention_EscDismissesSuggestionsOnly(t *testing.T) {
+	app := newCommentsTestApp(&mentionService{})
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	typeRunes(app, "@a")
+	runMentionSearch(t, app)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !app.commenting || app.mentions.visible() {
+		t.Fatal("expected esc to hide suggestions and keep the textarea open")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.commenting {
+		t.Error("expected second esc to cancel the comment")
+	}
+}
+
+func TestMention_StaleResultsIgnored(t *testing.T) {
+	var p MentionPicker
+	ta := textarea.New()
+	ta.SetWidth(40)
+	ta.SetValue("@a")
+	p.Refresh(ta)
+	stale := p.gen
+	ta.InsertString("l")
+	p.Refresh(ta)
+
+	p.SetResults([]model.User{{Login: "alice"}}, stale)
+	if p.visible() {
+		t.Error("expected results for an older query to be ignored")
+	}
+	p.SetResults([]model.User{{Login: "alice"}}, p.gen)
+	if !p.visible() {
+		t.Error("expected current results to be shown")
+	}
+}
+
+func TestMention_CompleteInIssueDescription(t *testing.T) {
+	app := newCommentsTestApp(&mentionService{})
+	d := &app.issueDialog
+	d.OpenCreate([]model.Project{{ID: "0-1", Name: "Proj", ShortName: "PROJ"}}, nil)
+	d.focusIndex = fieldDescription
+	d.updateFocus()
+
+	typeRunes(app, "ask @ali")
+	runMentionSearch(t, app)
+	if !strings.Contains(d.View(100, 40), "@alice (Alice A)") {
+		t.Error("expected suggestions below the description")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := d.descInput.Value(); got != "ask @alice " {
+		t.Errorf("expected tab to complete the mention, got %q", got)
+	}
+	if d.focusIndex != fieldDescription {
+		t.Error("expected focus to stay on the description")
+	}
+}
@@ -13,7 +13,8 @@ type moreIssuesLoadedMsg struct {
 }
 
 type issueDetailLoadedMsg struct {
-	issue *model.Issue
+	issue     *model.Issue
+	workItems []model.WorkItem
 }
 
 type projectsLoadedMsg struct {
@@ -24,7 +25,9 @@ type projectsForPickerMsg struct {
 	projects []model.Project
 }
 
-type issueCreatedMsg struct{}
+type issueCreatedMsg struct {
+	notice string // set when the issue exists but a follow-up step failed
+}
 
 type issueUpdatedMsg struct{}
 
@@ -32,6 +35,122 @@ type issueDeletedMsg struct{}
 
 type commentAddedMsg struct{}
 
+type commentUpdatedMsg struct{}
+
+type commentDeletedMsg struct{}
+
+type commentEditorFinishedMsg struct {
+	err      error
+	tempPath string
+	issueID  string
+	comment  *model.Comment // comment being edited; nil posts a new one
+	initial  string         // text the editor was opened with
+}
+
+type linksChangedMsg struct{}
+
+type workItemsLoadedMsg struct {
+	issueID   string
+	workItems []model.WorkItem
+}
+
+type workLoggedMsg struct {
+	issueID   string
+	minutes   int
+	fromTimer bool
+}
+
+type historyLoadedMsg struct {
+	issueID    string
+	activities []model.Activity
+	err        error // shown in the history pane instead of the feed
+}
+
+type tagsLoadedMsg struct {
+	tags []model.Tag
+}
+
+// tagsChangedMsg carries the issue's tags after an add or remove.
+type tagsChangedMsg struct {
+	issueID string
+	tags    []model.Tag
+}
+
+type timerTickMsg struct {
+	gen int
+}
+
+type workItemTypesLoadedMsg struct {
+	projectID string
+	types     []model.WorkItemType
+}
+
+type agilesLoadedMsg struct {
+	agiles []model.Agile
+}
+
+// boardSprintLoadedMsg carries a sprint of the board, with its issues.
+type boardSprintLoadedMsg struct {
+	agileID string
+	sprint  *model.Sprint
+}
+
+// sprintBoardsLoadedMsg carries the agile boards for the sprint picker.
+type sprintBoardsLoadedMsg struct {
+	agiles []model.Agile
+}
+
+type sprintsLoadedMsg struct {
+	agileID string
+	sprints []model.Sprint
+}
+
+// sprintIssueChangedMsg reports that an issue was added to or removed from
+// a sprint.
+type sprintIssueChangedMsg struct {
+	issueID string
+	sprint  model.Sprint
+	removed bool
+}
+
+// savedQueriesLoadedMsg carries the user's YouTrack saved searches.
+type savedQueriesLoadedMsg struct {
+	queries []model.SavedQuery
+}
+
+// savedSearchCountMsg carries the counts of a saved search; failed is set
+// when its query could not be run.
+type savedSearchCountMsg struct {
+	name   string
+	total  int
+	unread int
+	failed bool
+}
+
+// projectFieldsLoadedMsg carries a project's custom fields, which give the
+// order of fields in the detail view.
+type projectFieldsLoadedMsg struct {
+	projectID string
+	fields    []model.ProjectCustomField
+	retry     bool // the request failed for a reason that may not last
+}
+
+type linkTypesLoadedMsg struct {
+	types []model.IssueLinkType
+}
+
+type attachmentOpenedMsg struct {
+	path string // temp copy handed to the opener
+}
+
+type attachmentSavedMsg struct {
+	path string
+}
+
+type attachmentUploadedMsg struct {
+	name string
+}
+
 type errMsg struct {
 	err error
 }
@@ -45,6 +164,17 @@ type finderSearchResultsMsg struct {
 	generation int
 }
 
+type queryAssistDebounceMsg struct {
+	generation int
+}
+
+// queryAssistResultsMsg carries completions for the search prompt; assist
+// is nil when the request failed.
+type queryAssistResultsMsg struct {
+	assist     *model.SearchAssist
+	generation int
+}
+
 type assigneeDebounceMsg struct {
 	generation int
 }
@@ -54,6 +184,15 @@ type assigneeSearchResultsMsg struct {
 	generation int
 }
 
+type mentionDebounceMsg struct {
+	generation int
+}
+
+type mentionSearchResultsMsg struct {
+	users      []model.User
+	generation int
+}
+
 type customFieldsLoadedMsg struct {
 	fields []model.ProjectCustomField
 }
@@ -69,5 +208,20 @@ type mentionsLoadedMsg struct {
 type editorFinishedMsg struct {
 	err      error
 	tempPath string
-	original *model.Issue
+	original *model.Issue // nil when creating
+}
+
+// issueDraftReadyMsg carries a draft temp file, listing the project's
+// fields, to open in the editor.
+type issueDraftReadyMsg struct {
+	original *model.Issue // nil when creating
+	tempPath string
+}
+
+// issueDraftRejectedMsg hands an issue draft back to the editor with the
+// problem that stopped it from being saved.
+type issueDraftRejectedMsg struct {
+	original *model.Issue // nil when creating
+	content  string
+	problem  string
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -335,7 +336,7 @@ type mentionRecordingService struct {
 	lastQuery string
 }
 
-func (m *mentionRecordingService) ListIssues(query string, skip, top int) ([]model.Issue, error) {
+func (m *mentionRecordingService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
 	m.lastQuery = query
 	return []model.Issue{}, nil
 }
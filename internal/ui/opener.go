@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// openerCommand returns the desktop "open with default application" command.
+func openerCommand() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "open"
+	case "windows":
+		return "explorer"
+	default:
+		return "xdg-open"
+	}
+}
+
+// startOpener hands a file or URL to the desktop opener without waiting for it.
+// A variable so tests can stub it out.
+var startOpener = func(target string) error {
+	cmd := exec.Command(openerCommand(), target)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// maxAssistSuggestions caps the completions shown above the search prompt.
+const maxAssistSuggestions = 8
+
+// QueryAssist completes YouTrack queries in the search prompt. After each
+// edit the owner calls Refresh; when the query or caret moved a debounced
+// request to the server is scheduled, guarded by a generation counter like
+// the finder. The server also reports the parts of the query it can't
+// parse, which the prompt highlights.
+type QueryAssist struct {
+	query       string // query and caret the results are for
+	caret       int
+	suggestions []model.SearchSuggestion
+	errors      []model.StyleRange
+	cursor      int
+	gen         int
+	dismissed   bool // esc hid the suggestions for the current query
+}
+
+// Reset clears the suggestions and drops any pending request.
+func (q *QueryAssist) Reset() {
+	q.query = ""
+	q.caret = 0
+	q.suggestions = nil
+	q.errors = nil
+	q.cursor = 0
+	q.dismissed = false
+	q.gen++
+}
+
+// Refresh schedules a debounced request when the input's text or caret
+// changed since the last one.
+func (q *QueryAssist) Refresh(ti textinput.Model) tea.Cmd {
+	query, caret := ti.Value(), ti.Position()
+	if query == q.query && caret == q.caret {
+		return nil
+	}
+	if query != q.query {
+		q.suggestions = nil // offsets and errors were for the old text
+		q.errors = nil
+	}
+	q.query = query
+	q.caret = caret
+	q.dismissed = false
+	q.gen++
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+	gen := q.gen
+	return tea.Tick(300*time.Millisecond, func(t time.Time) tea.Msg {
+		return queryAssistDebounceMsg{generation: gen}
+	})
+}
+
+// SetResults handles the server's answer with generation guard.
+func (q *QueryAssist) SetResults(assist *model.SearchAssist, gen int) {
+	if gen != q.gen || assist == nil {
+		return
+	}
+	q.suggestions = assist.Suggestions
+	q.errors = assist.Errors()
+	q.cursor = 0
+}
+
+func (q *QueryAssist) visible() bool {
+	return !q.dismissed && len(q.suggestions) > 0
+}
+
+// HandleKey navigates and accepts suggestions while they are shown. up and
+// down are left to the query history. Returns false when the key should go
+// to the input instead.
+func (q *QueryAssist) HandleKey(msg tea.KeyMsg, ti *textinput.Model) bool {
+	if !q.visible() {
+		return false
+	}
+	switch msg.String() {
+	case "ctrl+p":
+		if q.cursor > 0 {
+			q.cursor--
+		}
+	case "ctrl+n":
+		if q.cursor < len(q.suggestions)-1 {
+			q.cursor++
+		}
+	case "tab":
+		value, caret := q.suggestions[q.cursor].Apply(ti.Value())
+		ti.SetValue(value)
+		ti.SetCursor(caret)
+	case "esc":
+		q.dismissed = true
+	default:
+		return false
+	}
+	return true
+}
+
+// View renders the suggestions in a box, or "" when there are none to show.
+func (q *QueryAssist) View(width int) string {
+	if !q.visible() {
+		return ""
+	}
+	selectedStyle := lipgloss.NewStyle().Background(lipgloss.Color("237")).Foreground(lipgloss.Color("255"))
+
+	start := 0
+	if q.cursor >= maxAssistSuggestions {
+		start = q.cursor - maxAssistSuggestions + 1
+	}
+	end := min(start+maxAssistSuggestions, len(q.suggestions))
+
+	optionWidth := 0
+	for _, s := range q.suggestions[start:end] {
+		optionWidth = max(optionWidth, lipgloss.Width(s.Option))
+	}
+	lineWidth := min(width-4, 60)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		s := q.suggestions[i]
+		line := s.Option + strings.Repeat(" ", optionWidth-lipgloss.Width(s.Option))
+		if s.Description != "" {
+			line += "  " + s.Description
+		}
+		if lipgloss.Width(line) > lineWidth && lineWidth > 1 {
+			line = ansiTruncate(line, lineWidth-1) + "…"
+		}
+		line += strings.Repeat(" ", max(lineWidth-lipgloss.Width(line), 0))
+		if i == q.cursor {
+			line = selectedStyle.Render(line)
+		} else {
+			line = highlightOption(line, s)
+		}
+		lines = append(lines, line)
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// highlightOption picks out the part of a suggestion's option that matches
+// the typed text and dims its description.
+func highlightOption(line string, s model.SearchSuggestion) string {
+	runes := []rune(line)
+	option := len([]rune(s.Option))
+	start := min(max(s.MatchingStart, 0), option)
+	end := min(max(s.MatchingEnd, start), option)
+	if option > len(runes) {
+		return line
+	}
+	return string(runes[:start]) + keyStyle.Render(string(runes[start:end])) +
+		string(runes[end:option]) + hintDescStyle.Render(string(runes[option:]))
+}
+
+// highlightErrors renders the search prompt with the parts of the query the
+// server couldn't parse marked, and the cursor at caret. Used in place of
+// the input's own view while there are errors to show.
+func highlightErrors(ti textinput.Model, errs []model.StyleRange, width int) string {
+	runes := []rune(ti.Value())
+	inError := make([]bool, len(runes)+1)
+	for _, e := range errs {
+		for i := max(e.Start, 0); i < e.Start+e.Length && i < len(runes); i++ {
+			inError[i] = true
+		}
+	}
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Underline(true)
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+
+	var b strings.Builder
+	b.WriteString(ti.Prompt)
+	caret := ti.Position()
+	for i, r := range append(runes, ' ') {
+		ch := string(r)
+		switch {
+		case i == caret:
+			b.WriteString(cursorStyle.Render(ch))
+		case i == len(runes):
+		case inError[i]:
+			b.WriteString(errStyle.Render(ch))
+		default:
+			b.WriteString(ch)
+		}
+	}
+	line := b.String()
+	if lipgloss.Width(line) > width && width > 1 {
+		line = ansiTruncate(line, width-1) + "…"
+	}
+	return line
+}
+
+// searchPromptView renders the search prompt, highlighting syntax errors
+// the server reported for the current text.
+func (a *App) searchPromptView() string {
+	q := &a.queryAssist
+	if len(q.errors) > 0 && q.query == a.searchInput.Value() {
+		return highlightErrors(a.searchInput, q.errors, a.width)
+	}
+	return a.searchInput.View()
+}
+
+// queryAssistCmd requests completions for the query and caret the assist
+// last saw. Failures are ignored; completions are only a convenience.
+func (a *App) queryAssistCmd(gen int) tea.Cmd {
+	query, caret := a.queryAssist.query, a.queryAssist.caret
+	service := a.service
+	ctx := a.supersede(&a.assistCancel)
+	return func() tea.Msg {
+		assist, err := service.SearchAssist(ctx, query, caret)
+		if err != nil {
+			return queryAssistResultsMsg{generation: gen}
+		}
+		return queryAssistResultsMsg{assist: assist, generation: gen}
+	}
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// assistService completes "sta" to State and flags "foo:" as an error.
+type assistService struct {
+	mockService
+	requests []string
+}
+
+func (s *assistService) SearchAssist(ctx context.Context, query string, caret int) (*model.SearchAssist, error) {
+	s.requests = append(s.requests, query)
+	assist := &model.SearchAssist{Query: query, Caret: caret}
+	if strings.HasSuffix(query, "sta") {
+		start := len([]rune(query)) - 3
+		assist.Suggestions = []model.SearchSuggestion{
+			{Option: "State", Suffix: ": ", Description: "field", CompletionStart: start, CompletionEnd: start + 3, MatchingEnd: 3},
+			{Option: "Star", Description: "tag", CompletionStart: start, CompletionEnd: start + 3, MatchingEnd: 3},
+		}
+	}
+	if i := strings.Index(query, "foo:"); i >= 0 {
+		assist.StyleRanges = []model.StyleRange{{Start: i, Length: 4, Style: "error"}}
+	}
+	return assist, nil
+}
+
+func openSearchPrompt(svc IssueService) *App {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	return app
+}
+
+// settleAssist delivers the pending debounce and the server's answer.
+func settleAssist(app *App) {
+	_, cmd := app.Update(queryAssistDebounceMsg{generation: app.queryAssist.gen})
+	if cmd != nil {
+		app.Update(cmd())
+	}
+}
+
+func TestQueryAssist_CompletesWithTab(t *testing.T) {
+	svc := &assistService{}
+	app := openSearchPrompt(svc)
+	typeKeys(app, "sta")
+	settleAssist(app)
+
+	view := app.View()
+	for _, want := range []string{"State  field", "Star   tag"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected suggestion %q:\n%s", want, view)
+		}
+	}
+	if len(svc.requests) != 1 || svc.requests[0] != "sta" {
+		t.Errorf("expected one request for the typed text, got %q", svc.requests)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlN})
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := app.searchInput.Value(); got != "State: " {
+		t.Errorf("got %q after completing, want %q", got, "State: ")
+	}
+	if app.searchInput.Position() != 7 {
+		t.Errorf("expected the cursor after the completion, got %d", app.searchInput.Position())
+	}
+	if cmd == nil {
+		t.Error("expected completions to be requested for the new text")
+	}
+}
+
+func TestQueryAssist_EscHidesSuggestionsFirst(t *testing.T) {
+	app := openSearchPrompt(&assistService{})
+	typeKeys(app, "sta")
+	settleAssist(app)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !app.searching {
+		t.Fatal("expected esc to hide the suggestions, not close the prompt")
+	}
+	if strings.Contains(app.View(), "Star   tag") {
+		t.Error("expected the suggestions to be hidden")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.searching {
+		t.Error("expected a second esc to close the prompt")
+	}
+}
+
+func TestQueryAssist_IgnoresStaleResults(t *testing.T) {
+	app := openSearchPrompt(&assistService{})
+	typeKeys(app, "sta")
+	gen := app.queryAssist.gen
+	typeKeys(app, "t")
+
+	if _, cmd := app.Update(queryAssistDebounceMsg{generation: gen}); cmd != nil {
+		t.Error("expected a superseded debounce to be dropped")
+	}
+	app.Update(queryAssistResultsMsg{
+		assist:     &model.SearchAssist{Suggestions: []model.SearchSuggestion{{Option: "State"}}},
+		generation: gen,
+	})
+	if len(app.queryAssist.suggestions) != 0 {
+		t.Error("expected results for older text to be ignored")
+	}
+}
+
+func TestQueryAssist_HistoryWhenNoSuggestions(t *testing.T) {
+	app := NewApp(&assistService{}, config.State{QueryHistory: []string{"#Unresolved"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := app.searchInput.Value(); got != "#Unresolved" {
+		t.Errorf("expected up to recall history without suggestions, got %q", got)
+	}
+}
+
+func TestQueryAssist_UpRecallsHistoryWhileSuggesting(t *testing.T) {
+	app := NewApp(&assistService{}, config.State{QueryHistory: []string{"#Unresolved"}})
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	typeKeys(app, "sta")
+	settleAssist(app)
+	if !app.queryAssist.visible() {
+		t.Fatal("expected suggestions after typing")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := app.searchInput.Value(); got != "#Unresolved" {
+		t.Errorf("expected up to recall history, got %q", got)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := app.searchInput.Value(); got != "sta" {
+		t.Errorf("expected down to return to the typed query, got %q", got)
+	}
+}
+
+func TestQueryAssist_HighlightsErrors(t *testing.T) {
+	app := openSearchPrompt(&assistService{})
+	typeKeys(app, "foo: bar")
+	settleAssist(app)
+
+	if len(app.queryAssist.errors) != 1 {
+		t.Fatalf("expected the error range to be kept, got %+v", app.queryAssist.errors)
+	}
+	if got := app.searchPromptView(); !strings.Contains(got, "/ foo: bar") {
+		t.Errorf("expected the query in the highlighted prompt, got %q", got)
+	}
+
+	typeKeys(app, "x")
+	if len(app.queryAssist.errors) != 0 {
+		t.Error("expected errors for the old text to be cleared when typing")
+	}
+}
+
+func TestOverlayAbove_KeepsRestOfLine(t *testing.T) {
+	bg := "aaaaaa\nbbbbbb\nstatus"
+	got := overlayAbove(bg, "XY", 2)
+	want := "aaaaaa\nbbXYbb\nstatus"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestOverlayAbove_WideCharacters(t *testing.T) {
+	bg := "日本語\n" + lipgloss.NewStyle().Bold(true).Render("界界界界") + "x\nstatus"
+
+	// Columns 3 and 4 are the second halves of wide characters
+	got := strings.Split(overlayAbove(bg, "XY", 3), "\n")
+	if w := lipgloss.Width(got[1]); w != 9 {
+		t.Errorf("line width changed to %d, want 9: %q", w, got[1])
+	}
+	if want := "界 XY 界x"; ansi.Strip(got[1]) != want {
+		t.Errorf("got %q, want %q", ansi.Strip(got[1]), want)
+	}
+
+	got = strings.Split(overlayAbove(bg, "XY", 2), "\n")
+	if want := "界XY界界x"; ansi.Strip(got[1]) != want {
+		t.Errorf("got %q, want %q", ansi.Strip(got[1]), want)
+	}
+}
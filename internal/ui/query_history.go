@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxQueryHistory caps the number of remembered search queries.
+const maxQueryHistory = 100
+
+// queryHistory remembers executed search queries, newest first. In the
+// search prompt, up/down step through them and ctrl+r recalls one by fuzzy
+// matching, like a shell's reverse search.
+type queryHistory struct {
+	entries []string
+	browse  int    // index of the entry shown while stepping with up/down; -1 when not
+	draft   string // prompt text from before browsing or recall started
+	recall  bool   // ctrl+r recall is active
+	pattern textinput.Model
+	matches []string
+	match   int
+}
+
+func newQueryHistory(entries []string) queryHistory {
+	ti := textinput.New()
+	ti.Prompt = "(history) "
+	ti.Placeholder = "type to search past queries"
+	h := queryHistory{pattern: ti, browse: -1}
+	for i := len(entries) - 1; i >= 0; i-- {
+		h.Add(entries[i])
+	}
+	return h
+}
+
+// Add records an executed query as the newest entry, dropping an earlier
+// copy of it and the oldest entries beyond the cap.
+func (h *queryHistory) Add(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+	entries := []string{query}
+	for _, e := range h.entries {
+		if e != query && len(entries) < maxQueryHistory {
+			entries = append(entries, e)
+		}
+	}
+	h.entries = entries
+}
+
+// Reset ends browsing and recall, as when the prompt is opened or closed.
+func (h *queryHistory) Reset() {
+	h.browse = -1
+	h.recall = false
+	h.pattern.Blur()
+}
+
+// Older returns the entry before the one shown, keeping current as the
+// draft when browsing starts. Entries equal to the draft are skipped, since
+// stepping to them would change nothing. ok is false at the oldest entry.
+func (h *queryHistory) Older(current string) (query string, ok bool) {
+	draft := h.draft
+	if h.browse < 0 {
+		draft = current
+	}
+	i := h.browse + 1
+	for i < len(h.entries) && h.entries[i] == draft {
+		i++
+	}
+	if i >= len(h.entries) {
+		return "", false
+	}
+	h.draft = draft
+	h.browse = i
+	return h.entries[i], true
+}
+
+// Newer returns the entry after the one shown, or the draft after the
+// newest. ok is false when not browsing.
+func (h *queryHistory) Newer() (query string, ok bool) {
+	if h.browse < 0 {
+		return "", false
+	}
+	h.browse--
+	for h.browse >= 0 && h.entries[h.browse] == h.draft {
+		h.browse--
+	}
+	if h.browse < 0 {
+		return h.draft, true
+	}
+	return h.entries[h.browse], true
+}
+
+// StartRecall begins a fuzzy search of the history; current is restored if
+// it is cancelled.
+func (h *queryHistory) StartRecall(current string) tea.Cmd {
+	h.recall = true
+	h.browse = -1
+	h.draft = current
+	h.pattern.SetValue("")
+	h.refilter()
+	return h.pattern.Focus()
+}
+
+// Match returns the recalled entry, or "" when nothing matches.
+func (h *queryHistory) Match() string {
+	if h.match < len(h.matches) {
+		return h.matches[h.match]
+	}
+	return ""
+}
+
+func (h *queryHistory) refilter() {
+	h.matches = fuzzyFilter(h.entries, h.pattern.Value())
+	h.match = 0
+}
+
+// UpdateRecall handles a key while recalling. ctrl+r and up step to older
+// matches, down to newer ones; other keys edit the pattern.
+func (h *queryHistory) UpdateRecall(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+r", "up", "ctrl+p":
+		if h.match < len(h.matches)-1 {
+			h.match++
+		}
+		return nil
+	case "down", "ctrl+n":
+		if h.match > 0 {
+			h.match--
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	h.pattern, cmd = h.pattern.Update(msg)
+	h.refilter()
+	return cmd
+}
+
+// View renders the recall prompt with the match it would pick.
+func (h *queryHistory) View() string {
+	view := h.pattern.View()
+	if match := h.Match(); match != "" {
+		return view + hintDescStyle.Render("  → ") + highlightMatch(match, h.pattern.Value())
+	}
+	if len(h.entries) == 0 {
+		return view + errorStyle.Render("  no queries yet")
+	}
+	return view + errorStyle.Render("  no match")
+}
+
+// fuzzyScore reports whether every character of pattern appears in s in
+// order, ignoring case, and scores the match: higher when the characters
+// are adjacent and start early.
+func fuzzyScore(s, pattern string) (int, bool) {
+	text := []rune(strings.ToLower(s))
+	pat := []rune(strings.ToLower(pattern))
+	score, run, last := 0, 0, -1
+	for _, p := range pat {
+		i := last + 1
+		for i < len(text) && text[i] != p {
+			i++
+		}
+		if i == len(text) {
+			return 0, false
+		}
+		if i == last+1 {
+			run++
+			score += 2 * run
+		} else {
+			run = 0
+		}
+		if last < 0 {
+			score -= i
+		}
+		last = i
+	}
+	return score, true
+}
+
+// fuzzyFilter returns the entries matching pattern, best first and newest
+// first among equal scores. An empty pattern matches every entry.
+func fuzzyFilter(entries []string, pattern string) []string {
+	type scored struct {
+		entry string
+		score int
+	}
+	var matches []scored
+	for _, e := range entries {
+		if score, ok := fuzzyScore(e, pattern); ok {
+			matches = append(matches, scored{e, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.entry
+	}
+	return result
+}
+
+// highlightMatch renders s with the characters matched by pattern picked
+// out, taking the earliest match of each in turn.
+func highlightMatch(s, pattern string) string {
+	pat := []rune(strings.ToLower(pattern))
+	var b strings.Builder
+	for _, r := range s {
+		if len(pat) > 0 && strings.ToLower(string(r)) == string(pat[0]) {
+			b.WriteString(keyStyle.Render(string(r)))
+			pat = pat[1:]
+			continue
+		}
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+)
+
+func TestQueryHistory_AddDeduplicatesAndCaps(t *testing.T) {
+	h := newQueryHistory(nil)
+	h.Add("a")
+	h.Add("b")
+	h.Add("  a ")
+	h.Add("")
+	if want := []string{"a", "b"}; !reflect.DeepEqual(h.entries, want) {
+		t.Errorf("got %q, want %q", h.entries, want)
+	}
+
+	for i := 0; i < maxQueryHistory+10; i++ {
+		h.Add(fmt.Sprintf("q%d", i))
+	}
+	if len(h.entries) != maxQueryHistory {
+		t.Fatalf("got %d entries, want %d", len(h.entries), maxQueryHistory)
+	}
+	if h.entries[0] != fmt.Sprintf("q%d", maxQueryHistory+9) {
+		t.Errorf("expected the newest entry first, got %q", h.entries[0])
+	}
+}
+
+func TestQueryHistory_LoadKeepsOrder(t *testing.T) {
+	h := newQueryHistory([]string{"newest", "older", "newest"})
+	if want := []string{"newest", "older"}; !reflect.DeepEqual(h.entries, want) {
+		t.Errorf("got %q, want %q", h.entries, want)
+	}
+}
+
+func TestQueryHistory_BrowseSkipsCurrent(t *testing.T) {
+	h := newQueryHistory([]string{"second", "first"})
+
+	if got, ok := h.Older("second"); !ok || got != "first" {
+		t.Errorf("got %q, %v; want the entry before the current query", got, ok)
+	}
+	if got, ok := h.Newer(); !ok || got != "second" {
+		t.Errorf("got %q, %v; want the draft back", got, ok)
+	}
+	if _, ok := h.Newer(); ok {
+		t.Error("expected browsing to have ended")
+	}
+}
+
+func TestQueryHistory_Browse(t *testing.T) {
+	h := newQueryHistory([]string{"second", "first"})
+
+	if _, ok := h.Newer(); ok {
+		t.Error("expected nothing newer before browsing")
+	}
+	steps := []struct {
+		older bool
+		want  string
+		ok    bool
+	}{
+		{true, "second", true},
+		{true, "first", true},
+		{true, "", false},
+		{false, "second", true},
+		{false, "typed", true},
+		{false, "", false},
+	}
+	for i, s := range steps {
+		var got string
+		var ok bool
+		if s.older {
+			got, ok = h.Older("typed")
+		} else {
+			got, ok = h.Newer()
+		}
+		if ok != s.ok || (ok && got != s.want) {
+			t.Errorf("step %d: got %q, %v; want %q, %v", i, got, ok, s.want, s.ok)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	entries := []string{"project: WEB #Unresolved", "for: me #Unresolved", "Type: Bug"}
+
+	if got := fuzzyFilter(entries, ""); !reflect.DeepEqual(got, entries) {
+		t.Errorf("empty pattern: got %q", got)
+	}
+	if got := fuzzyFilter(entries, "unres"); len(got) != 2 || got[0] != "for: me #Unresolved" {
+		t.Errorf("expected the earlier match first, got %q", got)
+	}
+	if got := fuzzyFilter(entries, "pwu"); len(got) != 1 || got[0] != entries[0] {
+		t.Errorf("expected a subsequence match, got %q", got)
+	}
+	if got := fuzzyFilter(entries, "bug"); len(got) != 1 || got[0] != "Type: Bug" {
+		t.Errorf("expected a case-insensitive match, got %q", got)
+	}
+	if got := fuzzyFilter(entries, "zzz"); len(got) != 0 {
+		t.Errorf("expected no match, got %q", got)
+	}
+}
+
+func typeKeys(app *App, s string) {
+	for _, r := range s {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestSearchPrompt_HistoryAndRecall(t *testing.T) {
+	svc := &savedSearchService{}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.statePath = filepath.Join(t.TempDir(), "state.yaml")
+
+	for _, q := range []string{"Type: Bug", "for: me"} {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+		app.searchInput.SetValue(q)
+		app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if got := app.searchInput.Value(); got != "for: me" {
+		t.Fatalf("expected the prompt to open with the last query, got %q", got)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if got := app.searchInput.Value(); got != "Type: Bug" {
+		t.Errorf("expected up to skip the query already shown, got %q", got)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := app.searchInput.Value(); got != "for: me" {
+		t.Errorf("expected down to return to the prompt's query, got %q", got)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyUp})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	typeKeys(app, "tb")
+	if view := app.View(); !strings.Contains(view, "(history) tb") || !strings.Contains(view, "→ Type: Bug") {
+		t.Errorf("expected the recall prompt with its match:\n%s", view)
+	}
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || app.searching || app.query != "Type: Bug" {
+		t.Fatalf("expected the recalled query to run, got %q", app.query)
+	}
+	cmd()
+	if last := svc.queries[len(svc.queries)-1]; last != "Type: Bug" {
+		t.Errorf("expected issues to be listed for the recalled query, got %q", last)
+	}
+
+	app.saveState()
+	state := config.LoadStateFromPath(app.statePath)
+	if want := []string{"Type: Bug", "for: me"}; !reflect.DeepEqual(state.QueryHistory, want) {
+		t.Errorf("got saved history %q, want %q", state.QueryHistory, want)
+	}
+}
+
+func TestSearchPrompt_RecallCancelRestoresDraft(t *testing.T) {
+	app := NewApp(&mockService{}, config.State{QueryHistory: []string{"Type: Bug"}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	app.searchInput.SetValue("draft")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	typeKeys(app, "bug")
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if !app.searching || app.queryHistory.recall {
+		t.Fatal("expected esc to return to the search prompt")
+	}
+	if got := app.searchInput.Value(); got != "draft" {
+		t.Errorf("expected the draft back, got %q", got)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !app.searching || app.searchInput.Value() != "Type: Bug" {
+		t.Errorf("expected tab to put the match in the prompt, got %q", app.searchInput.Value())
+	}
+}
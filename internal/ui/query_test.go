@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"context"
+	"io"
 	"testing"
 
 	"github.com/cf/lazytrack/internal/config"
@@ -41,6 +43,20 @@ func TestEffectiveQuery_WithActiveProject_EmptyQuery(t *testing.T) {
 	}
 }
 
+func TestEffectiveQuery_WithActiveSprint(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.activeProject = &model.Project{ShortName: "PROJ"}
+	app.sprintBoard = &model.Agile{Name: "Team board"}
+	app.activeSprint = &model.Sprint{Name: "Sprint4"}
+	app.query = "#Unresolved"
+
+	got := app.effectiveQuery()
+	want := "project: PROJ Board {Team board}: Sprint4 #Unresolved"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestEffectiveQuery_ManualProjectOverride(t *testing.T) {
 	app := NewApp(&mockService{}, config.DefaultState())
 	app.activeProject = &model.Project{ShortName: "PROJ"}
@@ -221,7 +237,7 @@ type capturingMockService struct {
 	lastQuery string
 }
 
-func (m *capturingMockService) ListIssues(query string, skip, top int) ([]model.Issue, error) {
+func (m *capturingMockService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
 	m.lastQuery = query
 	return nil, nil
 }
@@ -247,18 +263,108 @@ func TestFetchMoreIssuesCmd_UsesEffectiveQuery(t *testing.T) {
 // mockService implements IssueService for testing.
 type mockService struct{}
 
-func (m *mockService) GetCurrentUser() (*model.User, error)                          { return nil, nil }
-func (m *mockService) ListIssues(query string, skip, top int) ([]model.Issue, error) { return nil, nil }
-func (m *mockService) GetIssue(issueID string) (*model.Issue, error)                 { return nil, nil }
-func (m *mockService) CreateIssue(projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
+func (m *mockService) GetCurrentUser(ctx context.Context) (*model.User, error) { return nil, nil }
+func (m *mockService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
+	return nil, nil
+}
+func (m *mockService) ListIssueUpdateTimes(ctx context.Context, query string, top int) ([]int64, error) {
+	return nil, nil
+}
+func (m *mockService) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
+	return nil, nil
+}
+func (m *mockService) CreateIssue(ctx context.Context, projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
+	return nil, nil
+}
+func (m *mockService) UpdateIssue(ctx context.Context, issueID string, fields map[string]any) error {
+	return nil
+}
+func (m *mockService) DeleteIssue(ctx context.Context, issueID string) error { return nil }
+func (m *mockService) ListComments(ctx context.Context, issueID string) ([]model.Comment, error) {
 	return nil, nil
 }
-func (m *mockService) UpdateIssue(issueID string, fields map[string]any) error { return nil }
-func (m *mockService) DeleteIssue(issueID string) error                        { return nil }
-func (m *mockService) ListComments(issueID string) ([]model.Comment, error)    { return nil, nil }
-func (m *mockService) AddComment(issueID, text string) (*model.Comment, error) { return nil, nil }
-func (m *mockService) ListProjects() ([]model.Project, error)                  { return nil, nil }
-func (m *mockService) SearchUsers(query string) ([]model.User, error)          { return nil, nil }
-func (m *mockService) ListProjectCustomFields(projectID string) ([]model.ProjectCustomField, error) {
+func (m *mockService) IssueURL(issueID, commentID string) string {
+	return "https://yt.example.com/issue/" + issueID + "#" + commentID
+}
+func (m *mockService) AddComment(ctx context.Context, issueID, text string) (*model.Comment, error) {
+	return nil, nil
+}
+func (m *mockService) UpdateComment(ctx context.Context, issueID, commentID, text string) (*model.Comment, error) {
+	return nil, nil
+}
+func (m *mockService) DeleteComment(ctx context.Context, issueID, commentID string) error {
+	return nil
+}
+func (m *mockService) ListProjects(ctx context.Context) ([]model.Project, error) { return nil, nil }
+func (m *mockService) SearchUsers(ctx context.Context, query string) ([]model.User, error) {
+	return nil, nil
+}
+func (m *mockService) ListProjectCustomFields(ctx context.Context, projectID string) ([]model.ProjectCustomField, error) {
+	return nil, nil
+}
+func (m *mockService) DownloadAttachment(ctx context.Context, attachment model.Attachment, w io.Writer) error {
+	return nil
+}
+func (m *mockService) UploadAttachment(ctx context.Context, issueID, name string, r io.Reader) ([]model.Attachment, error) {
 	return nil, nil
 }
+func (m *mockService) ListLinkTypes(ctx context.Context) ([]model.IssueLinkType, error) {
+	return nil, nil
+}
+func (m *mockService) AddIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	return nil
+}
+func (m *mockService) RemoveIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	return nil
+}
+func (m *mockService) ListWorkItems(ctx context.Context, issueID string) ([]model.WorkItem, error) {
+	return nil, nil
+}
+func (m *mockService) CreateWorkItem(ctx context.Context, issueID string, item model.WorkItem) (*model.WorkItem, error) {
+	return nil, nil
+}
+func (m *mockService) ListWorkItemTypes(ctx context.Context, projectID string) ([]model.WorkItemType, error) {
+	return nil, nil
+}
+func (m *mockService) ListTags(ctx context.Context) ([]model.Tag, error) {
+	return nil, nil
+}
+func (m *mockService) ListActivities(ctx context.Context, issueID string) ([]model.Activity, error) {
+	return nil, nil
+}
+func (m *mockService) AddIssueTag(ctx context.Context, issueID, tagID string) error {
+	return nil
+}
+func (m *mockService) RemoveIssueTag(ctx context.Context, issueID, tagID string) error {
+	return nil
+}
+func (m *mockService) ListAgiles(ctx context.Context) ([]model.Agile, error) {
+	return nil, nil
+}
+func (m *mockService) GetSprint(ctx context.Context, agileID, sprintID string) (*model.Sprint, error) {
+	return &model.Sprint{ID: sprintID}, nil
+}
+func (m *mockService) ListSprints(ctx context.Context, agileID string) ([]model.Sprint, error) {
+	return nil, nil
+}
+func (m *mockService) AddIssueToSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	return nil
+}
+func (m *mockService) RemoveIssueFromSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	return nil
+}
+func (m *mockService) ListSavedQueries(ctx context.Context) ([]model.SavedQuery, error) {
+	return nil, nil
+}
+func (m *mockService) CreateSavedQuery(ctx context.Context, name, query string) (*model.SavedQuery, error) {
+	return &model.SavedQuery{Name: name, Query: query}, nil
+}
+func (m *mockService) UpdateSavedQuery(ctx context.Context, id, query string) (*model.SavedQuery, error) {
+	return &model.SavedQuery{ID: id, Query: query}, nil
+}
+func (m *mockService) DeleteSavedQuery(ctx context.Context, id string) error {
+	return nil
+}
+func (m *mockService) SearchAssist(ctx context.Context, query string, caret int) (*model.SearchAssist, error) {
+	return &model.SearchAssist{Query: query, Caret: caret}, nil
+}
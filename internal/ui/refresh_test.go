@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,12 +17,12 @@ type recordingService struct {
 	getIssueCalls   []string
 }
 
-func (r *recordingService) ListIssues(query string, skip, top int) ([]model.Issue, error) {
+func (r *recordingService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
 	r.listIssuesCalls++
 	return nil, nil
 }
 
-func (r *recordingService) GetIssue(issueID string) (*model.Issue, error) {
+func (r *recordingService) GetIssue(ctx context.Context, issueID string) (*model.Issue, error) {
 	r.getIssueCalls = append(r.getIssueCalls, issueID)
 	return &model.Issue{IDReadable: issueID}, nil
 }
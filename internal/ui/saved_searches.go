@@ -0,0 +1,487 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type savedSearchAction int
+
+const (
+	savedSearchOpen savedSearchAction = iota
+	savedSearchSave
+	savedSearchDelete
+)
+
+// maxSavedSearchCount caps the issues counted for a saved search; larger
+// totals are shown as "100+".
+const maxSavedSearchCount = 100
+
+// savedSearch is an entry of the saved searches list.
+type savedSearch struct {
+	name     string
+	query    string
+	local    bool   // kept in the config file
+	remoteID string // YouTrack saved query ID; "" if not synced
+	counting bool
+	counted  bool
+	total    int
+	unread   int // issues updated since the search was last opened
+	countErr bool
+}
+
+// SavedSearchDialog is a centered popup listing named searches from the
+// config and, when syncing is enabled, the user's YouTrack saved queries.
+// Each shows how many issues it matches and how many of them changed since
+// it was last opened. The current query can be saved under a new name.
+type SavedSearchDialog struct {
+	searches      []savedSearch
+	cursor        int
+	currentQuery  string // query to save with "a"
+	naming        bool   // the name input is focused
+	nameInput     textinput.Model
+	confirmDelete bool
+	loading       bool // YouTrack saved queries are being loaded
+	active        bool
+	submitted     bool
+	action        savedSearchAction
+	chosen        savedSearch // set on submit; only the name is set when saving
+	err           string
+}
+
+func NewSavedSearchDialog() SavedSearchDialog {
+	ti := textinput.New()
+	ti.Placeholder = "Name for the current search"
+	ti.Prompt = "Name: "
+	ti.CharLimit = 128
+	return SavedSearchDialog{nameInput: ti}
+}
+
+// Open activates the dialog with the searches from the config. With
+// loadingRemote set, YouTrack saved queries are expected through AddRemote.
+func (d *SavedSearchDialog) Open(saved []config.SavedSearch, currentQuery string, loadingRemote bool) {
+	d.searches = nil
+	for _, s := range saved {
+		d.searches = append(d.searches, savedSearch{name: s.Name, query: s.Query, local: true})
+	}
+	d.currentQuery = currentQuery
+	d.cursor = 0
+	d.naming = false
+	d.confirmDelete = false
+	d.loading = loadingRemote
+	d.active = true
+	d.submitted = false
+	d.chosen = savedSearch{}
+	d.err = ""
+	d.nameInput.Blur()
+}
+
+func (d *SavedSearchDialog) Close() {
+	d.active = false
+	d.nameInput.Blur()
+}
+
+// AddRemote merges YouTrack saved queries into the list. A query with the
+// name of a search from the config is linked to it rather than listed twice.
+func (d *SavedSearchDialog) AddRemote(queries []model.SavedQuery) {
+	d.loading = false
+	for _, q := range queries {
+		if i := d.find(q.Name); i >= 0 {
+			d.searches[i].remoteID = q.ID
+			continue
+		}
+		d.searches = append(d.searches, savedSearch{name: q.Name, query: q.Query, remoteID: q.ID})
+	}
+}
+
+// SetCount records the counts of the named search; failed marks a search
+// whose query could not be run.
+func (d *SavedSearchDialog) SetCount(name string, total, unread int, failed bool) {
+	if i := d.find(name); i >= 0 {
+		s := &d.searches[i]
+		s.counting = false
+		s.counted = true
+		s.total = total
+		s.unread = unread
+		s.countErr = failed
+	}
+}
+
+// SetError shows err inside the dialog.
+func (d *SavedSearchDialog) SetError(err string) {
+	d.loading = false
+	d.err = err
+}
+
+// toCount returns the searches whose counts haven't been requested yet and
+// marks them as being counted.
+func (d *SavedSearchDialog) toCount() []savedSearch {
+	var pending []savedSearch
+	for i := range d.searches {
+		s := &d.searches[i]
+		if !s.counted && !s.counting {
+			s.counting = true
+			pending = append(pending, *s)
+		}
+	}
+	return pending
+}
+
+func (d *SavedSearchDialog) find(name string) int {
+	for i, s := range d.searches {
+		if strings.EqualFold(s.name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *SavedSearchDialog) submit(action savedSearchAction, s savedSearch) {
+	d.action = action
+	d.chosen = s
+	d.submitted = true
+	d.Close()
+}
+
+func (d *SavedSearchDialog) Update(msg tea.Msg) (SavedSearchDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+	key := keyMsg.String()
+
+	if d.naming {
+		switch key {
+		case "esc":
+			d.naming = false
+			d.nameInput.Blur()
+			return *d, nil
+		case "enter":
+			name := strings.TrimSpace(d.nameInput.Value())
+			if name == "" {
+				d.err = "Name is required"
+				return *d, nil
+			}
+			s := savedSearch{name: name, query: d.currentQuery}
+			if i := d.find(name); i >= 0 {
+				s.name = d.searches[i].name
+				s.remoteID = d.searches[i].remoteID
+			}
+			d.submit(savedSearchSave, s)
+			return *d, nil
+		}
+		var cmd tea.Cmd
+		d.nameInput, cmd = d.nameInput.Update(msg)
+		d.err = ""
+		return *d, cmd
+	}
+
+	if d.confirmDelete {
+		d.confirmDelete = false
+		if key == "y" {
+			d.submit(savedSearchDelete, d.searches[d.cursor])
+		}
+		return *d, nil
+	}
+
+	switch key {
+	case "esc", "q":
+		d.Close()
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.searches)-1 {
+			d.cursor++
+		}
+	case "enter":
+		if len(d.searches) > 0 {
+			d.submit(savedSearchOpen, d.searches[d.cursor])
+		}
+	case "a":
+		if d.currentQuery == "" {
+			d.err = "Search with / first, then save the query here"
+			return *d, nil
+		}
+		d.naming = true
+		d.err = ""
+		d.nameInput.SetValue("")
+		return *d, d.nameInput.Focus()
+	case "d", "x":
+		if len(d.searches) > 0 {
+			d.confirmDelete = true
+			d.err = ""
+		}
+	}
+	return *d, nil
+}
+
+func (d *SavedSearchDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width / 2
+	if dialogWidth < 60 {
+		dialogWidth = 60
+	}
+	contentWidth := dialogWidth - 6
+
+	normalStyle := lipgloss.NewStyle().Width(contentWidth)
+	selectedStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Background(lipgloss.Color("237")).
+		Foreground(lipgloss.Color("255"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Saved Searches") + "\n\n")
+
+	if len(d.searches) == 0 && !d.loading {
+		b.WriteString(dim.Render("No saved searches") + "\n")
+	}
+	for i, s := range d.searches {
+		counts := savedSearchCounts(s)
+		name := s.name
+		if s.remoteID != "" {
+			name += " " + iconSynced
+		}
+		queryWidth := contentWidth - lipgloss.Width(name) - lipgloss.Width(counts) - 4
+		query := ""
+		if queryWidth > 3 {
+			query = s.query
+			if lipgloss.Width(query) > queryWidth {
+				query = ansiTruncate(query, queryWidth-1) + "…"
+			}
+		}
+		gap := contentWidth - lipgloss.Width(name) - lipgloss.Width(query) - lipgloss.Width(counts) - 2
+		if gap < 1 {
+			gap = 1
+		}
+		var line string
+		if i == d.cursor && !d.naming {
+			line = selectedStyle.Render(name + "  " + query + strings.Repeat(" ", gap) + counts)
+		} else {
+			unread := dim
+			if s.unread > 0 {
+				unread = mentionBadgeStyle
+			}
+			line = normalStyle.Render(name + "  " + dim.Render(query) + strings.Repeat(" ", gap) + unread.Render(counts))
+		}
+		b.WriteString(line + "\n")
+	}
+	if d.loading {
+		b.WriteString(dim.Render("Loading YouTrack saved searches...") + "\n")
+	}
+
+	if d.naming {
+		b.WriteString("\n" + dim.Render("Query: "+d.currentQuery) + "\n")
+		b.WriteString(d.nameInput.View() + "\n")
+	}
+	if d.confirmDelete {
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Delete %q? (y/n)", d.searches[d.cursor].name)) + "\n")
+	}
+	if d.err != "" {
+		b.WriteString("\n" + errorStyle.Render("Error: "+d.err) + "\n")
+	}
+
+	hint := "j/k: navigate  enter: search  a: save current  d: delete  esc: close"
+	if d.naming {
+		hint = "enter: save  esc: back"
+	}
+	b.WriteString("\n" + dim.Render(hint))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// savedSearchCounts formats a search's counts, e.g. "3 new · 42".
+func savedSearchCounts(s savedSearch) string {
+	switch {
+	case s.countErr:
+		return "?"
+	case !s.counted:
+		return "…"
+	}
+	total := fmt.Sprint(s.total)
+	if s.total > maxSavedSearchCount {
+		total = fmt.Sprintf("%d+", maxSavedSearchCount)
+	}
+	if s.unread == 0 {
+		return total
+	}
+	return fmt.Sprintf("%d new · %s", s.unread, total)
+}
+
+// openSavedSearches opens the saved searches list and starts loading what
+// it shows.
+func (a *App) openSavedSearches() tea.Cmd {
+	var saved []config.SavedSearch
+	sync := false
+	if a.cfg != nil {
+		saved = a.cfg.Searches.Saved
+		sync = a.cfg.Searches.Sync
+	}
+	a.savedSearches.Open(saved, a.query, sync)
+	if !sync {
+		return a.savedSearchCountsCmd()
+	}
+	service := a.service
+	ctx := a.ctx
+	return tea.Batch(a.savedSearchCountsCmd(), func() tea.Msg {
+		queries, err := service.ListSavedQueries(ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+		return savedQueriesLoadedMsg{queries}
+	})
+}
+
+// savedSearchCountsCmd counts the issues of every listed search that
+// hasn't been counted yet, with the filters the list would apply when the
+// search is run.
+func (a *App) savedSearchCountsCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, s := range a.savedSearches.toCount() {
+		cmds = append(cmds, a.countSavedSearchCmd(s.name, a.queryWithFilters(s.query), a.searchesViewed[s.name]))
+	}
+	return tea.Batch(cmds...)
+}
+
+// countSavedSearchCmd counts the issues matching query, and those of them
+// updated after viewed (Unix milliseconds). One issue past the cap is
+// fetched to tell a capped total from an exact one. Failures only mark the
+// count.
+func (a *App) countSavedSearchCmd(name, query string, viewed int64) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		updates, err := service.ListIssueUpdateTimes(ctx, query, maxSavedSearchCount+1)
+		if err != nil {
+			return savedSearchCountMsg{name: name, failed: true}
+		}
+		unread := 0
+		for _, updated := range updates[:min(len(updates), maxSavedSearchCount)] {
+			if updated > viewed {
+				unread++
+			}
+		}
+		return savedSearchCountMsg{name: name, total: len(updates), unread: unread}
+	}
+}
+
+// savedSearchCmd acts on what the saved searches list was closed with.
+func (a *App) savedSearchCmd() tea.Cmd {
+	d := &a.savedSearches
+	d.submitted = false
+	s := d.chosen
+	switch d.action {
+	case savedSearchSave:
+		if err := a.saveSearch(s.name, s.query); err != nil {
+			a.err = describeError(err)
+			return nil
+		}
+		a.notice = "Saved search " + s.name
+		// The current results have just been seen; don't count them as new.
+		a.searchesViewed[s.name] = time.Now().UnixMilli()
+		a.saveState()
+		if a.cfg.Searches.Sync {
+			return a.syncSavedQueryCmd(s, false)
+		}
+		return nil
+	case savedSearchDelete:
+		if s.local {
+			if err := a.deleteSearch(s.name); err != nil {
+				a.err = describeError(err)
+				return nil
+			}
+		}
+		a.notice = "Deleted search " + s.name
+		if s.remoteID != "" {
+			return a.syncSavedQueryCmd(s, true)
+		}
+		return nil
+	}
+
+	a.query = s.query
+	a.searchInput.SetValue(s.query)
+	a.searchesViewed[s.name] = time.Now().UnixMilli()
+	a.saveState()
+	a.loading = true
+	return a.fetchIssuesCmd()
+}
+
+// syncSavedQueryCmd saves a search on the server, updating the saved query
+// it is linked to if there is one, or deletes that query when remove is set.
+func (a *App) syncSavedQueryCmd(s savedSearch, remove bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		var err error
+		switch {
+		case remove:
+			err = service.DeleteSavedQuery(ctx, s.remoteID)
+		case s.remoteID != "":
+			_, err = service.UpdateSavedQuery(ctx, s.remoteID, s.query)
+		default:
+			_, err = service.CreateSavedQuery(ctx, s.name, s.query)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return nil
+	}
+}
+
+// saveSearch adds a named search to the config, replacing one with the same
+// name, and writes the config file.
+func (a *App) saveSearch(name, query string) error {
+	if a.cfg == nil {
+		return fmt.Errorf("no config file to save the search to")
+	}
+	searches := &a.cfg.Searches.Saved
+	for i, s := range *searches {
+		if strings.EqualFold(s.Name, name) {
+			(*searches)[i] = config.SavedSearch{Name: s.Name, Query: query}
+			return config.Save(a.configPath, a.cfg)
+		}
+	}
+	*searches = append(*searches, config.SavedSearch{Name: name, Query: query})
+	return config.Save(a.configPath, a.cfg)
+}
+
+// deleteSearch removes a named search from the config and writes the
+// config file.
+func (a *App) deleteSearch(name string) error {
+	if a.cfg == nil {
+		return fmt.Errorf("no config file to delete the search from")
+	}
+	var kept []config.SavedSearch
+	for _, s := range a.cfg.Searches.Saved {
+		if !strings.EqualFold(s.Name, name) {
+			kept = append(kept, s)
+		}
+	}
+	a.cfg.Searches.Saved = kept
+	delete(a.searchesViewed, name)
+	return config.Save(a.configPath, a.cfg)
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// savedSearchService answers every query with the issues listed for it and
+// serves one YouTrack saved query.
+type savedSearchService struct {
+	mockService
+	results map[string][]model.Issue
+	queries []string
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (s *savedSearchService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
+	s.queries = append(s.queries, query)
+	return s.results[query], nil
+}
+
+func (s *savedSearchService) ListIssueUpdateTimes(ctx context.Context, query string, top int) ([]int64, error) {
+	var times []int64
+	for _, issue := range s.results[query] {
+		times = append(times, issue.Updated)
+	}
+	return times, nil
+}
+
+func (s *savedSearchService) ListSavedQueries(ctx context.Context) ([]model.SavedQuery, error) {
+	return []model.SavedQuery{
+		{ID: "117-1", Name: "Bugs", Query: "Type: Bug"},
+		{ID: "117-2", Name: "Team", Query: "Subsystem: UI"},
+	}, nil
+}
+
+func (s *savedSearchService) CreateSavedQuery(ctx context.Context, name, query string) (*model.SavedQuery, error) {
+	s.created = append(s.created, name+": "+query)
+	return &model.SavedQuery{ID: "117-3", Name: name, Query: query}, nil
+}
+
+func (s *savedSearchService) UpdateSavedQuery(ctx context.Context, id, query string) (*model.SavedQuery, error) {
+	s.updated = append(s.updated, id+": "+query)
+	return &model.SavedQuery{ID: id, Query: query}, nil
+}
+
+func (s *savedSearchService) DeleteSavedQuery(ctx context.Context, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func newSavedSearchApp(t *testing.T, svc *savedSearchService, searches config.SearchesConfig) *App {
+	t.Helper()
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.statePath = filepath.Join(t.TempDir(), "state.yaml")
+	app.configPath = filepath.Join(t.TempDir(), "config.yaml")
+	app.SetConfig(&config.Config{
+		Server:   config.ServerConfig{URL: "https://example.com", Token: "perm:test"},
+		Searches: searches,
+	})
+	return app
+}
+
+// runCmd runs cmd and feeds its messages, including batched ones, back to
+// the app.
+func runCmd(app *App, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runCmd(app, c)
+		}
+	case nil:
+	default:
+		_, next := app.Update(msg)
+		runCmd(app, next)
+	}
+}
+
+func pressLeader(app *App, key rune) tea.Cmd {
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+	return cmd
+}
+
+func TestSavedSearches_ListsCounts(t *testing.T) {
+	svc := &savedSearchService{results: map[string][]model.Issue{
+		"Type: Bug": {{IDReadable: "PROJ-1", Updated: 2000}, {IDReadable: "PROJ-2", Updated: 500}},
+	}}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{
+		Saved: []config.SavedSearch{{Name: "Bugs", Query: "Type: Bug"}},
+	})
+	app.searchesViewed["Bugs"] = 1000
+
+	runCmd(app, pressLeader(app, '/'))
+
+	if !app.savedSearches.active {
+		t.Fatal("expected the saved searches to open")
+	}
+	view := app.View()
+	for _, want := range []string{"Saved Searches", "Bugs", "Type: Bug", "1 new · 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the list:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Team") {
+		t.Error("expected YouTrack saved searches to be left out without sync")
+	}
+}
+
+func TestSavedSearchCounts(t *testing.T) {
+	tests := []struct {
+		s    savedSearch
+		want string
+	}{
+		{savedSearch{}, "…"},
+		{savedSearch{counted: true, countErr: true}, "?"},
+		{savedSearch{counted: true, total: 100}, "100"},
+		{savedSearch{counted: true, total: 101, unread: 3}, "3 new · 100+"},
+	}
+	for _, tt := range tests {
+		if got := savedSearchCounts(tt.s); got != tt.want {
+			t.Errorf("savedSearchCounts(%+v) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSavedSearches_CountsWithActiveFilters(t *testing.T) {
+	svc := &savedSearchService{results: map[string][]model.Issue{
+		"Type: Bug":               {{IDReadable: "PROJ-1"}, {IDReadable: "OTHER-1"}},
+		"project: PROJ Type: Bug": {{IDReadable: "PROJ-1"}},
+	}}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{
+		Saved: []config.SavedSearch{{Name: "Bugs", Query: "Type: Bug"}},
+	})
+	app.activeProject = &model.Project{ShortName: "PROJ"}
+
+	runCmd(app, pressLeader(app, '/'))
+
+	if s := app.savedSearches.searches[0]; s.total != 1 {
+		t.Errorf("expected the count to match the filtered list, got %d", s.total)
+	}
+}
+
+func TestSavedSearches_SaveMarksViewed(t *testing.T) {
+	svc := &savedSearchService{}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{})
+	app.query = "#Unresolved"
+	runCmd(app, pressLeader(app, '/'))
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	typeKeys(app, "Open")
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(app, cmd)
+
+	if app.searchesViewed["Open"] == 0 {
+		t.Error("expected a new search to start out as viewed")
+	}
+}
+
+func TestSavedSearches_RunMarksViewed(t *testing.T) {
+	svc := &savedSearchService{results: map[string][]model.Issue{
+		"Type: Bug": {{IDReadable: "PROJ-1", Updated: 2000}},
+	}}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{
+		Saved: []config.SavedSearch{{Name: "Bugs", Query: "Type: Bug"}},
+	})
+	runCmd(app, pressLeader(app, '/'))
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the issue list to reload")
+	}
+	cmd()
+
+	if app.query != "Type: Bug" || app.searchInput.Value() != "Type: Bug" {
+		t.Errorf("expected the search to run, got query %q", app.query)
+	}
+	if last := svc.queries[len(svc.queries)-1]; last != "Type: Bug" {
+		t.Errorf("expected issues to be listed for the search, got %q", last)
+	}
+	if app.searchesViewed["Bugs"] == 0 {
+		t.Error("expected the search to be marked as viewed")
+	}
+	if state := config.LoadStateFromPath(app.statePath); state.UI.SearchesViewed["Bugs"] == 0 {
+		t.Error("expected the view time to be saved in state")
+	}
+}
+
+func TestSavedSearches_SaveCurrentQuery(t *testing.T) {
+	svc := &savedSearchService{}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{Sync: true})
+	app.query = "#Unresolved"
+	runCmd(app, pressLeader(app, '/'))
+
+	if !strings.Contains(app.View(), "Team "+iconSynced) {
+		t.Errorf("expected synced searches in the list:\n%s", app.View())
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for _, r := range "Open" {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	runCmd(app, cmd)
+
+	cfg, err := config.LoadFromPath(app.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.SavedSearch{Name: "Open", Query: "#Unresolved"}
+	if len(cfg.Searches.Saved) != 1 || cfg.Searches.Saved[0] != want {
+		t.Errorf("expected the search in the config file, got %+v", cfg.Searches.Saved)
+	}
+	if len(svc.created) != 1 || svc.created[0] != "Open: #Unresolved" {
+		t.Errorf("expected the search to be saved in YouTrack, got %q", svc.created)
+	}
+}
+
+func TestSavedSearches_SaveUnderSyncedNameUpdates(t *testing.T) {
+	svc := &savedSearchService{}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{Sync: true})
+	app.query = "Type: Bug #Unresolved"
+
+	for range 2 {
+		runCmd(app, pressLeader(app, '/'))
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+		for _, r := range "bugs" {
+			app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		runCmd(app, cmd)
+	}
+
+	if len(svc.created) != 0 {
+		t.Errorf("expected no new saved queries, got %q", svc.created)
+	}
+	if len(svc.updated) != 2 || svc.updated[0] != "117-1: Type: Bug #Unresolved" {
+		t.Errorf("expected the synced query to be updated, got %q", svc.updated)
+	}
+}
+
+func TestSavedSearches_Delete(t *testing.T) {
+	svc := &savedSearchService{}
+	app := newSavedSearchApp(t, svc, config.SearchesConfig{
+		Sync:  true,
+		Saved: []config.SavedSearch{{Name: "Bugs", Query: "Type: Bug"}, {Name: "Mine", Query: "for: me"}},
+	})
+	runCmd(app, pressLeader(app, '/'))
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if app.savedSearches.submitted || !app.savedSearches.active {
+		t.Fatal("expected delete to ask for confirmation")
+	}
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	runCmd(app, cmd)
+
+	if got := app.cfg.Searches.Saved; len(got) != 1 || got[0].Name != "Mine" {
+		t.Errorf("expected Bugs to be removed from the config, got %+v", got)
+	}
+	if len(svc.deleted) != 1 || svc.deleted[0] != "117-1" {
+		t.Errorf("expected the synced query to be deleted, got %q", svc.deleted)
+	}
+}
+
+func TestSavedSearches_SaveNeedsQuery(t *testing.T) {
+	app := newSavedSearchApp(t, &savedSearchService{}, config.SearchesConfig{})
+	runCmd(app, pressLeader(app, '/'))
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if app.savedSearches.naming || app.savedSearches.err == "" {
+		t.Error("expected saving without a query to be refused")
+	}
+}
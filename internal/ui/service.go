@@ -1,18 +1,50 @@
 package ui
 
-import "github.com/cf/lazytrack/internal/model"
+import (
+	"context"
+	"io"
+
+	"github.com/cf/lazytrack/internal/model"
+)
 
 // IssueService defines the operations the UI needs from the API layer.
+// Every call takes a context so superseded or abandoned requests can be cancelled.
 type IssueService interface {
-	GetCurrentUser() (*model.User, error)
-	ListIssues(query string, skip, top int) ([]model.Issue, error)
-	GetIssue(issueID string) (*model.Issue, error)
-	CreateIssue(projectID, summary, description string, customFields []map[string]any) (*model.Issue, error)
-	UpdateIssue(issueID string, fields map[string]any) error
-	DeleteIssue(issueID string) error
-	ListComments(issueID string) ([]model.Comment, error)
-	AddComment(issueID, text string) (*model.Comment, error)
-	ListProjects() ([]model.Project, error)
-	SearchUsers(query string) ([]model.User, error)
-	ListProjectCustomFields(projectID string) ([]model.ProjectCustomField, error)
+	GetCurrentUser(ctx context.Context) (*model.User, error)
+	ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error)
+	ListIssueUpdateTimes(ctx context.Context, query string, top int) ([]int64, error)
+	GetIssue(ctx context.Context, issueID string) (*model.Issue, error)
+	IssueURL(issueID, commentID string) string
+	CreateIssue(ctx context.Context, projectID, summary, description string, customFields []map[string]any) (*model.Issue, error)
+	UpdateIssue(ctx context.Context, issueID string, fields map[string]any) error
+	DeleteIssue(ctx context.Context, issueID string) error
+	ListComments(ctx context.Context, issueID string) ([]model.Comment, error)
+	AddComment(ctx context.Context, issueID, text string) (*model.Comment, error)
+	UpdateComment(ctx context.Context, issueID, commentID, text string) (*model.Comment, error)
+	DeleteComment(ctx context.Context, issueID, commentID string) error
+	ListProjects(ctx context.Context) ([]model.Project, error)
+	SearchUsers(ctx context.Context, query string) ([]model.User, error)
+	ListProjectCustomFields(ctx context.Context, projectID string) ([]model.ProjectCustomField, error)
+	DownloadAttachment(ctx context.Context, attachment model.Attachment, w io.Writer) error
+	UploadAttachment(ctx context.Context, issueID, name string, r io.Reader) ([]model.Attachment, error)
+	ListLinkTypes(ctx context.Context) ([]model.IssueLinkType, error)
+	AddIssueLink(ctx context.Context, issueID, linkID, targetID string) error
+	RemoveIssueLink(ctx context.Context, issueID, linkID, targetID string) error
+	ListWorkItems(ctx context.Context, issueID string) ([]model.WorkItem, error)
+	CreateWorkItem(ctx context.Context, issueID string, item model.WorkItem) (*model.WorkItem, error)
+	ListWorkItemTypes(ctx context.Context, projectID string) ([]model.WorkItemType, error)
+	ListTags(ctx context.Context) ([]model.Tag, error)
+	ListActivities(ctx context.Context, issueID string) ([]model.Activity, error)
+	AddIssueTag(ctx context.Context, issueID, tagID string) error
+	RemoveIssueTag(ctx context.Context, issueID, tagID string) error
+	ListAgiles(ctx context.Context) ([]model.Agile, error)
+	GetSprint(ctx context.Context, agileID, sprintID string) (*model.Sprint, error)
+	ListSprints(ctx context.Context, agileID string) ([]model.Sprint, error)
+	AddIssueToSprint(ctx context.Context, agileID, sprintID, issueID string) error
+	RemoveIssueFromSprint(ctx context.Context, agileID, sprintID, issueID string) error
+	ListSavedQueries(ctx context.Context) ([]model.SavedQuery, error)
+	CreateSavedQuery(ctx context.Context, name, query string) (*model.SavedQuery, error)
+	UpdateSavedQuery(ctx context.Context, id, query string) (*model.SavedQuery, error)
+	DeleteSavedQuery(ctx context.Context, id string) error
+	SearchAssist(ctx context.Context, query string, caret int) (*model.SearchAssist, error)
 }
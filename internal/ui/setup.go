@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -32,6 +33,7 @@ type SetupModel struct {
 	step       setupStep
 	err        string
 	cfg        *config.Config
+	base       config.Config // settings kept when re-running setup
 	cancelled  bool
 }
 
@@ -53,6 +55,17 @@ func NewSetupModel() *SetupModel {
 	}
 }
 
+// Reauthenticate prepares the model to replace the token of an existing
+// config: the server URL is pre-filled and the token input focused.
+// Settings other than URL and token are preserved on save.
+func (m *SetupModel) Reauthenticate(cfg *config.Config) {
+	m.base = *cfg
+	m.urlInput.SetValue(cfg.Server.URL)
+	m.urlInput.Blur()
+	m.tokenInput.Focus()
+	m.step = stepToken
+}
+
 func (m *SetupModel) Config() *config.Config {
 	return m.cfg
 }
@@ -81,19 +94,17 @@ func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case setupValidateMsg:
 		if msg.err != nil {
 			m.step = stepToken
-			m.err = msg.err.Error()
+			m.err = describeError(msg.err)
 			return m, m.tokenInput.Focus()
 		}
 		// Success — save config
 		m.step = stepDone
 		serverURL := strings.TrimRight(m.urlInput.Value(), "/")
 		token := m.tokenInput.Value()
-		m.cfg = &config.Config{
-			Server: config.ServerConfig{
-				URL:   serverURL,
-				Token: token,
-			},
-		}
+		cfg := m.base
+		cfg.Server.URL = serverURL
+		cfg.Server.Token = token
+		m.cfg = &cfg
 		path := config.DefaultPath()
 		if err := config.Save(path, m.cfg); err != nil {
 			m.err = fmt.Sprintf("Failed to save config: %v", err)
@@ -137,7 +148,7 @@ func (m *SetupModel) handleEnter() (tea.Model, tea.Cmd) {
 		token := m.tokenInput.Value()
 		return m, func() tea.Msg {
 			client := api.NewClient(serverURL, token)
-			user, err := client.GetCurrentUser()
+			user, err := client.GetCurrentUser(context.Background())
 			if err != nil {
 				return setupValidateMsg{err: err}
 			}
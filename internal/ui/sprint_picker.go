@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type sprintAction int
+
+const (
+	sprintSelect sprintAction = iota
+	sprintAddIssue
+	sprintRemoveIssue
+)
+
+// SprintPickerDialog is a centered popup listing the sprints of an agile
+// board. Choosing one scopes the issue list to it; the selected issue can
+// also be added to or removed from the sprint under the cursor.
+// Entry 0 is "(No sprint)"; sprints start at index 1.
+type SprintPickerDialog struct {
+	agiles           []model.Agile
+	agileIndex       int
+	sprints          []model.Sprint
+	issueID          string // issue to add or remove, "" if none is selected
+	cursor           int
+	sprintsRequested bool // app should load sprints of Agile() and call SetSprints
+	loading          bool
+	active           bool
+	submitted        bool
+	action           sprintAction
+	selectedSprint   *model.Sprint // set on submit; nil for no sprint
+	err              string
+}
+
+func NewSprintPickerDialog() SprintPickerDialog {
+	return SprintPickerDialog{}
+}
+
+// Open activates the dialog on the first board that includes the given
+// project, or the first board, and requests its sprints.
+func (d *SprintPickerDialog) Open(agiles []model.Agile, project, issueID string) {
+	d.agiles = agiles
+	d.agileIndex = projectAgileIndex(agiles, project)
+	d.issueID = issueID
+	d.active = true
+	d.submitted = false
+	d.selectedSprint = nil
+	d.err = ""
+	d.selectAgile(d.agileIndex)
+}
+
+func (d *SprintPickerDialog) Close() {
+	d.active = false
+}
+
+// Agile returns the board whose sprints are listed, or nil.
+func (d *SprintPickerDialog) Agile() *model.Agile {
+	if d.agileIndex < 0 || d.agileIndex >= len(d.agiles) {
+		return nil
+	}
+	return &d.agiles[d.agileIndex]
+}
+
+func (d *SprintPickerDialog) selectAgile(i int) {
+	d.agileIndex = i
+	d.sprints = nil
+	d.cursor = 0
+	d.loading = d.Agile() != nil
+	d.sprintsRequested = d.loading
+}
+
+// SetSprints lists the board's sprints that aren't archived, with the
+// cursor on the current one.
+func (d *SprintPickerDialog) SetSprints(sprints []model.Sprint) {
+	d.loading = false
+	d.sprints = nil
+	for _, s := range sprints {
+		if !s.Archived {
+			d.sprints = append(d.sprints, s)
+		}
+	}
+	d.cursor = 0
+	if agile := d.Agile(); agile != nil && agile.CurrentSprint != nil {
+		for i, s := range d.sprints {
+			if s.ID == agile.CurrentSprint.ID {
+				d.cursor = i + 1
+			}
+		}
+	}
+}
+
+// SetError shows err inside the dialog.
+func (d *SprintPickerDialog) SetError(err string) {
+	d.loading = false
+	d.err = err
+}
+
+func (d *SprintPickerDialog) Update(msg tea.Msg) (SprintPickerDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		d.Close()
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.sprints) {
+			d.cursor++
+		}
+	case "[", "]":
+		if len(d.agiles) > 1 {
+			step := 1
+			if keyMsg.String() == "[" {
+				step = len(d.agiles) - 1
+			}
+			d.selectAgile((d.agileIndex + step) % len(d.agiles))
+		}
+	case "enter":
+		if d.Agile() == nil {
+			return *d, nil
+		}
+		d.submitted = true
+		d.action = sprintSelect
+		d.selectedSprint = nil
+		if d.cursor > 0 {
+			s := d.sprints[d.cursor-1]
+			d.selectedSprint = &s
+		}
+		d.Close()
+	case "a", "x":
+		if d.issueID == "" || d.cursor == 0 {
+			return *d, nil
+		}
+		d.submitted = true
+		d.action = sprintAddIssue
+		if keyMsg.String() == "x" {
+			d.action = sprintRemoveIssue
+		}
+		s := d.sprints[d.cursor-1]
+		d.selectedSprint = &s
+		d.Close()
+	}
+	return *d, nil
+}
+
+func (d *SprintPickerDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width * 2 / 5
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	contentWidth := dialogWidth - 6
+
+	var b strings.Builder
+
+	title := "Select Sprint"
+	if agile := d.Agile(); agile != nil {
+		title += " — " + agile.Name
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+
+	normalStyle := lipgloss.NewStyle().Width(contentWidth)
+	selectedStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Background(lipgloss.Color("237")).
+		Foreground(lipgloss.Color("255"))
+	line := func(i int, s string) {
+		if lipgloss.Width(s) > contentWidth {
+			s = ansiTruncate(s, contentWidth-1) + "…"
+		}
+		if d.cursor == i {
+			b.WriteString(selectedStyle.Render(s) + "\n")
+		} else {
+			b.WriteString(normalStyle.Render(s) + "\n")
+		}
+	}
+
+	switch {
+	case d.Agile() == nil:
+		b.WriteString("No agile boards found.\n")
+	case d.loading:
+		b.WriteString("Loading sprints...\n")
+	default:
+		line(0, "(No sprint)")
+		for i, s := range d.sprints {
+			text := s.Name
+			if dates := sprintDates(s); dates != "" {
+				text += "  " + hintDescStyle.Render(dates)
+			}
+			if agile := d.Agile(); agile.CurrentSprint != nil && agile.CurrentSprint.ID == s.ID {
+				text += keyStyle.Render("  current")
+			}
+			line(i+1, text)
+		}
+	}
+
+	if d.err != "" {
+		b.WriteString("\n" + errorStyle.Render("Error: "+d.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	hints := "j/k: navigate  enter: select  [/]: board  esc: cancel"
+	if d.issueID != "" {
+		hints = "j/k: navigate  enter: select  a/x: add/remove " + d.issueID + "  [/]: board  esc: cancel"
+	}
+	b.WriteString(hint.Render(hints))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// sprintDates formats a sprint's start and finish, e.g. "Jul 1 – Jul 14".
+func sprintDates(s model.Sprint) string {
+	if s.Start == 0 || s.Finish == 0 {
+		return ""
+	}
+	return time.UnixMilli(s.Start).Format("Jan 2") + " – " + time.UnixMilli(s.Finish).Format("Jan 2")
+}
+
+// sprintStatus describes the active sprint for the status bar, e.g.
+// "Sprint 4 (Jul 1 – Jul 14, 5 days left)".
+func sprintStatus(s model.Sprint, now time.Time) string {
+	var details []string
+	if dates := sprintDates(s); dates != "" {
+		details = append(details, dates)
+	}
+	if days, ok := s.DaysLeft(now); ok {
+		switch {
+		case days <= 0:
+			details = append(details, "ended")
+		case days == 1:
+			details = append(details, "1 day left")
+		default:
+			details = append(details, fmt.Sprintf("%d days left", days))
+		}
+	}
+	if len(details) == 0 {
+		return s.Name
+	}
+	return s.Name + " (" + strings.Join(details, ", ") + ")"
+}
+
+// sprintQuery returns the search clause for issues in a sprint of a board,
+// e.g. "Board {Team board}: {Sprint 4}".
+func sprintQuery(agile model.Agile, sprint model.Sprint) string {
+	return "Board " + queryValue(agile.Name) + ": " + queryValue(sprint.Name)
+}
+
+// queryValue wraps a value in braces when it contains characters that
+// would end it in a search query, such as spaces.
+func queryValue(s string) string {
+	if strings.ContainsAny(s, " \t,:#(){}-") {
+		return "{" + s + "}"
+	}
+	return s
+}
+
+// sprintPickerCmd acts on what the sprint picker asked for: loading a
+// board's sprints, scoping the list to the chosen sprint, or adding or
+// removing the selected issue.
+func (a *App) sprintPickerCmd() tea.Cmd {
+	d := &a.sprintPicker
+	agile := d.Agile()
+	switch {
+	case d.submitted:
+		d.submitted = false
+		switch d.action {
+		case sprintAddIssue, sprintRemoveIssue:
+			a.loading = true
+			return a.changeSprintIssueCmd(agile.ID, *d.selectedSprint, d.issueID, d.action == sprintRemoveIssue)
+		}
+		a.activeSprint = d.selectedSprint
+		a.sprintBoard = nil
+		if a.activeSprint != nil {
+			board := *agile
+			a.sprintBoard = &board
+		}
+		a.loading = true
+		return a.fetchIssuesCmd()
+	case d.sprintsRequested:
+		d.sprintsRequested = false
+		return a.fetchSprintsCmd(agile.ID)
+	}
+	return nil
+}
+
+func (a *App) fetchSprintsCmd(agileID string) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		sprints, err := service.ListSprints(ctx, agileID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return sprintsLoadedMsg{agileID: agileID, sprints: sprints}
+	}
+}
+
+// changeSprintIssueCmd adds issueID to (or, with remove set, removes it
+// from) a sprint of the given board.
+func (a *App) changeSprintIssueCmd(agileID string, sprint model.Sprint, issueID string, remove bool) tea.Cmd {
+	service := a.service
+	ctx := a.ctx
+	return func() tea.Msg {
+		var err error
+		if remove {
+			err = service.RemoveIssueFromSprint(ctx, agileID, sprint.ID, issueID)
+		} else {
+			err = service.AddIssueToSprint(ctx, agileID, sprint.ID, issueID)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return sprintIssueChangedMsg{issueID: issueID, sprint: sprint, removed: remove}
+	}
+}
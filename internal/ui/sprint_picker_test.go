@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// sprintService serves the test board's sprints and records sprint changes.
+type sprintService struct {
+	boardService
+	queries []string
+	added   []string
+	removed []string
+}
+
+func (s *sprintService) ListSprints(ctx context.Context, agileID string) ([]model.Sprint, error) {
+	return []model.Sprint{
+		{ID: "109-2", Name: "Sprint 2", Archived: true},
+		{ID: "109-3", Name: "Sprint 3"},
+		{ID: "109-4", Name: "Sprint 4"},
+	}, nil
+}
+
+func (s *sprintService) ListIssues(ctx context.Context, query string, skip, top int) ([]model.Issue, error) {
+	s.queries = append(s.queries, query)
+	return nil, nil
+}
+
+func (s *sprintService) AddIssueToSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	s.added = append(s.added, agileID+" "+sprintID+" "+issueID)
+	return nil
+}
+
+func (s *sprintService) RemoveIssueFromSprint(ctx context.Context, agileID, sprintID, issueID string) error {
+	s.removed = append(s.removed, agileID+" "+sprintID+" "+issueID)
+	return nil
+}
+
+func openTestSprintPicker(t *testing.T, svc *sprintService) *App {
+	t.Helper()
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 30
+	app.selected = &model.Issue{IDReadable: "PROJ-7"}
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if cmd == nil {
+		t.Fatal("expected boards to load")
+	}
+	_, cmd = app.Update(cmd())
+	if !app.sprintPicker.active || cmd == nil {
+		t.Fatal("expected the sprint picker to open and load sprints")
+	}
+	app.Update(cmd())
+	return app
+}
+
+func TestSprintPicker_ListsSprints(t *testing.T) {
+	app := openTestSprintPicker(t, &sprintService{})
+
+	view := app.View()
+	for _, want := range []string{"Select Sprint — Team board", "(No sprint)", "Sprint 3", "Sprint 4  current"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the picker:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Sprint 2") {
+		t.Error("expected archived sprints to be left out")
+	}
+	if app.sprintPicker.cursor != 2 {
+		t.Errorf("expected the cursor on the current sprint, got %d", app.sprintPicker.cursor)
+	}
+}
+
+func TestSprintPicker_SelectScopesList(t *testing.T) {
+	svc := &sprintService{}
+	app := openTestSprintPicker(t, svc)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the issue list to reload")
+	}
+	app.Update(cmd())
+	if app.activeSprint == nil || app.activeSprint.ID != "109-4" {
+		t.Fatalf("expected Sprint 4 to be active, got %+v", app.activeSprint)
+	}
+	if len(svc.queries) != 1 || svc.queries[0] != "Board {Team board}: {Sprint 4}" {
+		t.Errorf("unexpected queries: %q", svc.queries)
+	}
+	if bar := app.renderStatusBar(); !strings.Contains(bar, "sprint: Sprint 4") {
+		t.Errorf("expected the sprint in the status bar:\n%s", bar)
+	}
+
+	app = openTestSprintPicker(t, svc)
+	app.sprintPicker.cursor = 0
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.activeSprint != nil {
+		t.Errorf("expected (No sprint) to clear the filter, got %+v", app.activeSprint)
+	}
+}
+
+func TestSprintPicker_AddAndRemoveIssue(t *testing.T) {
+	svc := &sprintService{}
+	app := openTestSprintPicker(t, svc)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if cmd == nil {
+		t.Fatal("expected an add command")
+	}
+	app.Update(cmd())
+	if len(svc.added) != 1 || svc.added[0] != "108-1 109-4 PROJ-7" {
+		t.Errorf("unexpected additions: %q", svc.added)
+	}
+	if app.notice != "Added PROJ-7 to Sprint 4" {
+		t.Errorf("unexpected notice: %q", app.notice)
+	}
+
+	app = openTestSprintPicker(t, svc)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected a remove command")
+	}
+	app.Update(cmd())
+	if len(svc.removed) != 1 || svc.removed[0] != "108-1 109-3 PROJ-7" {
+		t.Errorf("unexpected removals: %q", svc.removed)
+	}
+}
+
+func TestSprintPicker_OpensSameBoardAsBoardView(t *testing.T) {
+	agiles := []model.Agile{
+		{ID: "a-1", Projects: []model.Project{{ShortName: "OTHER"}}},
+		{ID: "a-2", Projects: []model.Project{{ShortName: "PROJ"}}},
+		{ID: "a-3", Projects: []model.Project{{ShortName: "PROJ"}}},
+	}
+	d := NewSprintPickerDialog()
+	d.Open(agiles, "PROJ", "")
+	b := NewBoardView()
+	b.Open(agiles, "PROJ")
+	if d.Agile().ID != "a-2" || b.Agile().ID != "a-2" {
+		t.Errorf("expected both on the first board with PROJ, got %s and %s", d.Agile().ID, b.Agile().ID)
+	}
+}
+
+func TestSprintStatus(t *testing.T) {
+	start := time.Date(2025, 7, 1, 9, 0, 0, 0, time.Local)
+	finish := time.Date(2025, 7, 14, 18, 0, 0, 0, time.Local)
+	s := model.Sprint{Name: "Sprint 4", Start: start.UnixMilli(), Finish: finish.UnixMilli()}
+
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, 7, 10, 12, 0, 0, 0, time.Local), "Sprint 4 (Jul 1 – Jul 14, 5 days left)"},
+		{time.Date(2025, 7, 14, 9, 0, 0, 0, time.Local), "Sprint 4 (Jul 1 – Jul 14, 1 day left)"},
+		{time.Date(2025, 7, 20, 9, 0, 0, 0, time.Local), "Sprint 4 (Jul 1 – Jul 14, ended)"},
+	}
+	for _, tt := range tests {
+		if got := sprintStatus(s, tt.now); got != tt.want {
+			t.Errorf("sprintStatus at %s = %q, want %q", tt.now, got, tt.want)
+		}
+	}
+	if got := sprintStatus(model.Sprint{Name: "Backlog"}, time.Now()); got != "Backlog" {
+		t.Errorf("expected just the name without dates, got %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -21,18 +22,35 @@ func (a *App) renderStatusBar() string {
 	if a.activeProject != nil {
 		left += hintDescStyle.Render(" | project: " + a.activeProject.ShortName)
 	}
+	if a.activeSprint != nil {
+		left += hintDescStyle.Render(" | sprint: " + sprintStatus(*a.activeSprint, time.Now()))
+	}
 	if a.query != "" {
 		left += hintDescStyle.Render(" | query: " + a.query)
 	}
+	if a.timer != nil {
+		left += keyStyle.Render(fmt.Sprintf(" | %s %s %s", iconTimer, a.timer.IssueID, formatElapsed(time.Since(a.timer.Started))))
+	}
 	if a.unreadMentionCount > 0 {
 		left += mentionBadgeStyle.Render(fmt.Sprintf(" · %d mentions", a.unreadMentionCount))
 	}
+	if a.notice != "" {
+		left += noticeStyle.Render(" | " + a.notice)
+	}
 	if a.loading {
-		left += keyStyle.Render(" | loading...")
+		if a.retry != nil {
+			left += keyStyle.Render(fmt.Sprintf(" | %s, retrying (%d/%d)...",
+				a.retry.Reason(), a.retry.Attempt+1, a.retry.MaxAttempts))
+		} else {
+			left += keyStyle.Render(" | loading...")
+		}
 	}
 
 	// Right side: mode-aware hints
 	hints := modeHints(a.commenting, a.focus)
+	if a.board.active {
+		hints = boardHints
+	}
 	rightParts := make([]string, len(hints))
 	for i, h := range hints {
 		rightParts[i] = formatKeyHint(h.key, h.desc)
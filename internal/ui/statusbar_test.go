@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/api"
+	"github.com/cf/lazytrack/internal/config"
+)
+
+func TestStatusBar_ShowsRetryWhileLoading(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.width = 200
+	app.loading = true
+
+	m, _ := app.Update(api.RetryEvent{Attempt: 1, MaxAttempts: 3, StatusCode: 503})
+	a := m.(*App)
+
+	bar := a.renderStatusBar()
+	if !strings.Contains(bar, "HTTP 503, retrying (2/3)") {
+		t.Errorf("status bar %q should show retry progress", bar)
+	}
+}
+
+func TestStatusBar_RetryClearedWhenIdle(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.width = 200
+	app.loading = true
+	app.Update(api.RetryEvent{Attempt: 1, MaxAttempts: 3, StatusCode: 503})
+
+	// Request finishes, then any later message drops the stale notice.
+	app.loading = false
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+
+	if app.retry != nil {
+		t.Error("expected retry notice to be cleared once idle")
+	}
+	app.loading = true
+	if bar := app.renderStatusBar(); strings.Contains(bar, "retrying") {
+		t.Errorf("status bar %q should not show a stale retry", bar)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
 )
 
 var (
@@ -22,10 +24,16 @@ var (
 
 // Nerd Font icon constants.
 const (
-	iconList    = "\uF0CA" // nf-fa-list_ul
-	iconFile    = "\uF15C" // nf-fa-file_text_o
-	iconApp     = "\uF188" // nf-fa-bug
-	iconComment = "💬"
+	iconList       = "\uF0CA" // nf-fa-list_ul
+	iconFile       = "\uF15C" // nf-fa-file_text_o
+	iconApp        = "\uF188" // nf-fa-bug
+	iconComment    = "💬"
+	iconAttachment = "📎"
+	iconLink       = "🔗"
+	iconWork       = "⏱"
+	iconTimer      = "⏲"
+	iconHistory    = "🕘"
+	iconSynced     = "☁"
 )
 
 // Chrome styles for the status bar key hints.
@@ -46,6 +54,9 @@ var (
 
 	filterInactiveStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")) // dim gray
+
+	noticeStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("78")) // green
 )
 
 // keyHint pairs a key with its description for the status bar.
@@ -77,22 +88,58 @@ var (
 		{"space", "actions"},
 		{"q", "quit"},
 	}
+	commentsHints = []keyHint{
+		{"j/k", "select"},
+		{"e", "edit"},
+		{"E", "$EDITOR"},
+		{"a", "quote"},
+		{"x", "delete"},
+		{"y", "copy"},
+		{"o", "open"},
+		{"tab", "cycle"},
+		{"space", "actions"},
+		{"q", "quit"},
+	}
+	boardHints = []keyHint{
+		{"←/→", "column"},
+		{"j/k", "card"},
+		{"h/l", "move card"},
+		{"enter", "open"},
+		{"s", "swimlanes"},
+		{"[/]", "board"},
+		{"r", "refresh"},
+		{"esc", "close"},
+	}
 	commentingHints = []keyHint{
 		{"ctrl+s", "submit"},
 		{"esc", "cancel"},
 	}
 	leaderHints = []keyHint{
+		{"/", "searches"},
 		{"a", "assign"},
+		{"b", "board"},
 		{"c", "create"},
+		{"C", "editor create"},
 		{"d", "delete"},
 		{"e", "edit"},
 		{"f", "find"},
+		{"F", "empty fields"},
+		{"g", "tags"},
+		{"h", "tree"},
+		{"H", "history"},
+		{"l", "links"},
 		{"m", "comment"},
+		{"M", "editor comment"},
 		{"n", "notifs"},
+		{"o", "attachments"},
 		{"p", "project"},
 		{"s", "state"},
+		{"S", "sprint"},
 		{"t", "toggle"},
+		{"T", "timer"},
+		{"u", "upload"},
 		{"v", "vim edit"},
+		{"w", "log work"},
 	}
 )
 
@@ -117,6 +164,8 @@ func modeHints(commenting bool, focus pane) []keyHint {
 		return commentingHints
 	case focus == listPane:
 		return listHints
+	case focus == commentsPane:
+		return commentsHints
 	default:
 		return detailHints
 	}
@@ -191,3 +240,30 @@ func stateColor(state string) string {
 		return state
 	}
 }
+
+// tagChip renders a tag as a chip in the tag's own colors, falling back to
+// gray for tags without a color.
+func tagChip(tag model.Tag) string {
+	style := lipgloss.NewStyle().
+		Padding(0, 1).
+		Background(lipgloss.Color("238")).
+		Foreground(lipgloss.Color("252"))
+	if tag.Color != nil {
+		if tag.Color.Background != "" {
+			style = style.Background(lipgloss.Color(tag.Color.Background))
+		}
+		if tag.Color.Foreground != "" {
+			style = style.Foreground(lipgloss.Color(tag.Color.Foreground))
+		}
+	}
+	return style.Render(tag.Name)
+}
+
+// renderTagChips renders tags as space-separated chips.
+func renderTagChips(tags []model.Tag) string {
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		chips[i] = tagChip(tag)
+	}
+	return strings.Join(chips, " ")
+}
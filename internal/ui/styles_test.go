@@ -43,6 +43,7 @@ func TestModeHints(t *testing.T) {
 		{"list mode", false, listPane, "navigate", "scroll"},
 		{"detail mode", false, detailPane, "scroll", "navigate"},
 		{"commenting mode", true, detailPane, "submit", "navigate"},
+		{"comments mode", false, commentsPane, "edit", "scroll"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
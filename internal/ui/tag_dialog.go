@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type tagAction int
+
+const (
+	tagAdd tagAction = iota
+	tagRemove
+)
+
+// maxTagMatches caps the suggestions shown under the tag input.
+const maxTagMatches = 8
+
+// filterTags returns the tags in all that are not in current and contain
+// query, case-insensitively. Prefix matches sort first, then by name.
+func filterTags(all, current []model.Tag, query string) []model.Tag {
+	applied := make(map[string]bool, len(current))
+	for _, t := range current {
+		applied[t.ID] = true
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var matches []model.Tag
+	for _, t := range all {
+		if applied[t.ID] || !strings.Contains(strings.ToLower(t.Name), query) {
+			continue
+		}
+		matches = append(matches, t)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		pi := strings.HasPrefix(strings.ToLower(matches[i].Name), query)
+		pj := strings.HasPrefix(strings.ToLower(matches[j].Name), query)
+		if pi != pj {
+			return pi
+		}
+		return strings.ToLower(matches[i].Name) < strings.ToLower(matches[j].Name)
+	})
+	return matches
+}
+
+// TagDialog is a centered popup for adding and removing an issue's tags.
+// Typing autocompletes from every tag the user can apply; tab moves to the
+// applied tags to remove one.
+type TagDialog struct {
+	issueID       string
+	current       []model.Tag // tags on the issue
+	all           []model.Tag // tags available to apply
+	matches       []model.Tag
+	cursor        int
+	removing      bool // focus is on the applied tags
+	chipCursor    int
+	tagsRequested bool // app should load tags and call SetTags
+	loadingTags   bool
+	input         textinput.Model
+	active        bool
+	submitted     bool
+	action        tagAction
+	tag           model.Tag // set on submit
+	err           string
+}
+
+func NewTagDialog() TagDialog {
+	ti := textinput.New()
+	ti.Placeholder = "Type to search tags..."
+	ti.Prompt = "Tag: "
+	ti.CharLimit = 128
+	return TagDialog{input: ti}
+}
+
+// Open activates the dialog for the issue. Tags are requested when all is
+// nil, i.e. they have not been loaded yet.
+func (d *TagDialog) Open(issue *model.Issue, all []model.Tag) tea.Cmd {
+	d.issueID = issue.IDReadable
+	d.current = issue.Tags
+	d.all = all
+	d.cursor = 0
+	d.removing = false
+	d.chipCursor = 0
+	d.tagsRequested = all == nil
+	d.loadingTags = d.tagsRequested
+	d.active = true
+	d.submitted = false
+	d.tag = model.Tag{}
+	d.err = ""
+	d.input.SetValue("")
+	d.refilter()
+	return d.input.Focus()
+}
+
+func (d *TagDialog) Close() {
+	d.active = false
+	d.input.Blur()
+}
+
+// SetTags provides the tags available to apply.
+func (d *TagDialog) SetTags(all []model.Tag) {
+	d.loadingTags = false
+	d.all = all
+	d.refilter()
+}
+
+// SetError shows err inside the dialog.
+func (d *TagDialog) SetError(err string) {
+	d.loadingTags = false
+	d.err = err
+}
+
+func (d *TagDialog) refilter() {
+	d.matches = filterTags(d.all, d.current, d.input.Value())
+	if d.cursor >= len(d.matches) {
+		d.cursor = 0
+	}
+}
+
+func (d *TagDialog) submit(action tagAction, tag model.Tag) {
+	d.action = action
+	d.tag = tag
+	d.submitted = true
+	d.Close()
+}
+
+func (d *TagDialog) Update(msg tea.Msg) (TagDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+	key := keyMsg.String()
+
+	if key == "esc" {
+		d.Close()
+		return *d, nil
+	}
+
+	if d.removing {
+		switch key {
+		case "tab", "shift+tab", "i":
+			d.removing = false
+			return *d, d.input.Focus()
+		case "left", "h":
+			if d.chipCursor > 0 {
+				d.chipCursor--
+			}
+		case "right", "l":
+			if d.chipCursor < len(d.current)-1 {
+				d.chipCursor++
+			}
+		case "x", "d", "backspace", "delete":
+			d.submit(tagRemove, d.current[d.chipCursor])
+		}
+		return *d, nil
+	}
+
+	switch key {
+	case "up", "ctrl+p":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+		return *d, nil
+	case "down", "ctrl+n":
+		if d.cursor < len(d.matches)-1 && d.cursor < maxTagMatches-1 {
+			d.cursor++
+		}
+		return *d, nil
+	case "enter":
+		if len(d.matches) == 0 {
+			d.err = "No matching tag"
+			return *d, nil
+		}
+		d.submit(tagAdd, d.matches[d.cursor])
+		return *d, nil
+	case "tab", "shift+tab":
+		if len(d.current) > 0 {
+			d.removing = true
+			d.err = ""
+			if d.chipCursor >= len(d.current) {
+				d.chipCursor = len(d.current) - 1
+			}
+			d.input.Blur()
+		}
+		return *d, nil
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	d.err = ""
+	d.refilter()
+	return *d, cmd
+}
+
+func (d *TagDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width * 2 / 5
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+	contentWidth := dialogWidth - 6
+
+	normalStyle := lipgloss.NewStyle().Width(contentWidth)
+	selectedStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Background(lipgloss.Color("237")).
+		Foreground(lipgloss.Color("255"))
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Tags — "+d.issueID) + "\n\n")
+
+	if len(d.current) == 0 {
+		b.WriteString(dim.Render("No tags") + "\n\n")
+	} else {
+		chips := make([]string, len(d.current))
+		for i, tag := range d.current {
+			chips[i] = tagChip(tag)
+			if d.removing && i == d.chipCursor {
+				chips[i] = keyStyle.Render("›") + chips[i]
+			}
+		}
+		b.WriteString(strings.Join(chips, " ") + "\n\n")
+	}
+
+	b.WriteString(d.input.View() + "\n")
+	switch {
+	case d.loadingTags:
+		b.WriteString(dim.Render("Loading tags...") + "\n")
+	case len(d.matches) == 0 && d.err == "":
+		b.WriteString(dim.Render("No matching tags") + "\n")
+	}
+	for i, tag := range d.matches {
+		if i >= maxTagMatches {
+			break
+		}
+		if i == d.cursor && !d.removing {
+			b.WriteString(selectedStyle.Render(tag.Name) + "\n")
+		} else {
+			b.WriteString(normalStyle.Render(tag.Name) + "\n")
+		}
+	}
+	if hidden := len(d.matches) - maxTagMatches; hidden > 0 {
+		b.WriteString(dim.Render(fmt.Sprintf("… %d more", hidden)) + "\n")
+	}
+
+	if d.err != "" {
+		b.WriteString("\n" + errorStyle.Render("Error: "+d.err) + "\n")
+	}
+
+	hint := "↑/↓: select  enter: add  tab: remove tags  esc: close"
+	if d.removing {
+		hint = "h/l: select  x: remove  tab: back  esc: close"
+	}
+	b.WriteString("\n" + dim.Render(hint))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// tagService records tag changes and serves a fixed set of tags.
+type tagService struct {
+	mockService
+	listCalls int
+	added     []string
+	removed   []string
+}
+
+func (s *tagService) ListTags(ctx context.Context) ([]model.Tag, error) {
+	s.listCalls++
+	return []model.Tag{
+		{ID: "6-1", Name: "regression"},
+		{ID: "6-2", Name: "needs-triage"},
+		{ID: "6-3", Name: "triaged"},
+	}, nil
+}
+
+func (s *tagService) AddIssueTag(ctx context.Context, issueID, tagID string) error {
+	s.added = append(s.added, issueID+" "+tagID)
+	return nil
+}
+
+func (s *tagService) RemoveIssueTag(ctx context.Context, issueID, tagID string) error {
+	s.removed = append(s.removed, issueID+" "+tagID)
+	return nil
+}
+
+func newTagTestApp(svc IssueService) (*App, tea.Cmd) {
+	issue := model.Issue{IDReadable: "PROJ-1", Tags: []model.Tag{{ID: "6-1", Name: "regression"}}}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+	app.issues = []model.Issue{issue}
+	app.list.SetItems(app.listItems())
+	app.selected = &issue
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	return app, cmd
+}
+
+func loadTags(app *App, cmd tea.Cmd) {
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, fn := range batch {
+			if fn == nil {
+				continue
+			}
+			if msg, ok := fn().(tagsLoadedMsg); ok {
+				app.Update(msg)
+			}
+		}
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	all := []model.Tag{{ID: "1", Name: "needs-triage"}, {ID: "2", Name: "Triaged"}, {ID: "3", Name: "regression"}}
+
+	got := filterTags(all, nil, "tria")
+	if len(got) != 2 || got[0].Name != "Triaged" || got[1].Name != "needs-triage" {
+		t.Errorf("expected prefix match first, got %+v", got)
+	}
+
+	got = filterTags(all, []model.Tag{{ID: "3"}}, "")
+	if len(got) != 2 {
+		t.Errorf("expected applied tags to be excluded, got %+v", got)
+	}
+}
+
+func TestTags_AddWithAutocomplete(t *testing.T) {
+	svc := &tagService{}
+	app, cmd := newTagTestApp(svc)
+	if !app.tagDialog.active {
+		t.Fatal("expected tag dialog after space g")
+	}
+	loadTags(app, cmd)
+	if len(app.tagDialog.matches) != 2 {
+		t.Fatalf("got %d matches, want 2 (applied tag excluded)", len(app.tagDialog.matches))
+	}
+
+	typeRunes(app, "tri")
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected add tag cmd")
+	}
+	msg, ok := cmd().(tagsChangedMsg)
+	if !ok {
+		t.Fatal("expected tagsChangedMsg")
+	}
+	if len(svc.added) != 1 || svc.added[0] != "PROJ-1 6-2" {
+		t.Errorf("unexpected adds: %v", svc.added)
+	}
+
+	app.Update(msg)
+	if len(app.issues[0].Tags) != 2 {
+		t.Errorf("expected list issue to carry the new tag, got %+v", app.issues[0].Tags)
+	}
+	item := app.list.Items()[0].(issueItem)
+	if !strings.Contains(item.Description(), "needs-triage") {
+		t.Errorf("expected tag chip in list description, got %q", item.Description())
+	}
+
+	// A second open uses the cached tags
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if svc.listCalls != 1 || app.tagDialog.loadingTags {
+		t.Errorf("expected cached tags, got %d fetches", svc.listCalls)
+	}
+}
+
+func TestTags_Remove(t *testing.T) {
+	svc := &tagService{}
+	app, cmd := newTagTestApp(svc)
+	loadTags(app, cmd)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected remove tag cmd")
+	}
+	msg := cmd().(tagsChangedMsg)
+	if len(svc.removed) != 1 || svc.removed[0] != "PROJ-1 6-1" {
+		t.Errorf("unexpected removals: %v", svc.removed)
+	}
+	if len(msg.tags) != 0 {
+		t.Errorf("expected no tags left, got %+v", msg.tags)
+	}
+}
+
+func TestTags_NoMatchKeepsDialogOpen(t *testing.T) {
+	app, cmd := newTagTestApp(&tagService{})
+	loadTags(app, cmd)
+
+	typeRunes(app, "zzz")
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !app.tagDialog.active || app.tagDialog.err == "" {
+		t.Error("expected an error and the dialog to stay open")
+	}
+}
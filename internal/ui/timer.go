@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// timerTickCmd schedules the next status bar refresh of the running timer.
+// Ticks carry the timer generation so a stopped timer's tick chain dies out
+// instead of doubling up with a newly started one.
+func (a *App) timerTickCmd() tea.Cmd {
+	gen := a.timerGen
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return timerTickMsg{gen: gen}
+	})
+}
+
+// toggleTimer starts a timer on the selected issue, or stops the running one
+// and logs the elapsed time as a work item on the issue it was started on.
+// The timer is only cleared once the work item is saved, so a failed request
+// can be retried without losing time.
+func (a *App) toggleTimer() tea.Cmd {
+	if a.timer != nil {
+		if a.timerStopping {
+			return nil
+		}
+		a.timerStopping = true
+		a.loading = true
+		item := model.WorkItem{
+			Date:     a.timer.Started.UnixMilli(),
+			Duration: model.Period{Minutes: timerMinutes(time.Since(a.timer.Started))},
+		}
+		return a.logWorkCmd(a.timer.IssueID, item, true)
+	}
+
+	if a.selected == nil {
+		return nil
+	}
+	a.timer = &config.TimerState{IssueID: a.selected.IDReadable, Started: time.Now()}
+	a.timerGen++
+	a.saveState()
+	a.notice = "Timer started on " + a.timer.IssueID
+	return a.timerTickCmd()
+}
+
+// timerMinutes rounds elapsed time to whole minutes for a work item.
+// YouTrack rejects zero durations, so anything shorter counts as a minute.
+func timerMinutes(elapsed time.Duration) int {
+	minutes := int(elapsed.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes
+}
+
+// formatElapsed renders a duration as a stopwatch, e.g. "1:02:03".
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func newTimerTestApp(t *testing.T, svc IssueService) *App {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 160
+	app.height = 40
+	app.statePath = t.TempDir() + "/state.yaml"
+	app.selected = &model.Issue{IDReadable: "PROJ-1"}
+	return app
+}
+
+func pressTimerKey(app *App) tea.Cmd {
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	return cmd
+}
+
+func TestTimer_StartPersistsState(t *testing.T) {
+	app := newTimerTestApp(t, &workService{})
+
+	if cmd := pressTimerKey(app); cmd == nil {
+		t.Fatal("expected tick cmd after starting timer")
+	}
+	if app.timer == nil || app.timer.IssueID != "PROJ-1" {
+		t.Fatalf("expected timer on PROJ-1, got %+v", app.timer)
+	}
+
+	saved := config.LoadStateFromPath(app.statePath)
+	if saved.Timer == nil || saved.Timer.IssueID != "PROJ-1" {
+		t.Errorf("expected running timer in saved state, got %+v", saved.Timer)
+	}
+	if !strings.Contains(app.renderStatusBar(), "PROJ-1 0:00:0") {
+		t.Error("expected elapsed time in status bar")
+	}
+}
+
+func TestTimer_StopLogsWork(t *testing.T) {
+	svc := &workService{}
+	state := config.DefaultState()
+	state.Timer = &config.TimerState{IssueID: "PROJ-2", Started: time.Now().Add(-25 * time.Minute)}
+	app := NewApp(svc, state)
+	app.ready = true
+	app.statePath = t.TempDir() + "/state.yaml"
+	app.selected = &model.Issue{IDReadable: "PROJ-1"}
+
+	cmd := pressTimerKey(app)
+	if cmd == nil {
+		t.Fatal("expected log work cmd when stopping timer")
+	}
+	msg := cmd()
+	if len(svc.logged) != 1 || svc.logged[0].Duration.Minutes != 25 {
+		t.Fatalf("expected 25m logged, got %+v", svc.logged)
+	}
+	if app.timer == nil {
+		t.Fatal("expected timer to keep running until the work item is saved")
+	}
+
+	app.Update(msg)
+	if app.timer != nil {
+		t.Error("expected timer cleared after logging")
+	}
+	if saved := config.LoadStateFromPath(app.statePath); saved.Timer != nil {
+		t.Errorf("expected no timer in saved state, got %+v", saved.Timer)
+	}
+	if app.notice != "Logged 25m on PROJ-2" {
+		t.Errorf("got notice %q", app.notice)
+	}
+}
+
+func TestTimer_FailedStopKeepsTimer(t *testing.T) {
+	app := newTimerTestApp(t, &workService{})
+	pressTimerKey(app)
+
+	pressTimerKey(app)
+	app.Update(errMsg{errors.New("boom")})
+
+	if app.timer == nil || app.timerStopping {
+		t.Error("expected timer to keep running after a failed stop")
+	}
+}
+
+func TestTimer_StaleTickIgnored(t *testing.T) {
+	app := newTimerTestApp(t, &workService{})
+	pressTimerKey(app)
+
+	if _, cmd := app.Update(timerTickMsg{gen: app.timerGen - 1}); cmd != nil {
+		t.Error("expected stale tick to be dropped")
+	}
+	if _, cmd := app.Update(timerTickMsg{gen: app.timerGen}); cmd == nil {
+		t.Error("expected current tick to schedule the next one")
+	}
+}
+
+func TestTimerMinutes(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{10 * time.Second, 1},
+		{89 * time.Second, 1},
+		{90 * time.Second, 2},
+		{2*time.Hour + 14*time.Minute, 134},
+	}
+	for _, tt := range tests {
+		if got := timerMinutes(tt.elapsed); got != tt.want {
+			t.Errorf("timerMinutes(%v) = %d, want %d", tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	if got := formatElapsed(time.Hour + 2*time.Minute + 3*time.Second + 400*time.Millisecond); got != "1:02:03" {
+		t.Errorf("got %q, want 1:02:03", got)
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// treeRow is an issue placed in the parent/subtask tree.
+type treeRow struct {
+	issue       model.Issue
+	depth       int
+	hasChildren bool
+	collapsed   bool
+}
+
+// buildIssueTree nests issues under their loaded parents, keeping the list
+// order among siblings. Issues whose parent is not loaded become roots.
+// Children of collapsed issues are omitted.
+func buildIssueTree(issues []model.Issue, collapsed map[string]bool) []treeRow {
+	loaded := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		loaded[issue.IDReadable] = true
+	}
+
+	children := map[string][]model.Issue{}
+	var roots []model.Issue
+	for _, issue := range issues {
+		parent := issue.ParentID()
+		if parent != "" && parent != issue.IDReadable && loaded[parent] {
+			children[parent] = append(children[parent], issue)
+		} else {
+			roots = append(roots, issue)
+		}
+	}
+
+	var rows []treeRow
+	visited := map[string]bool{}
+	var walk func(issue model.Issue, depth int)
+	walk = func(issue model.Issue, depth int) {
+		if visited[issue.IDReadable] {
+			return
+		}
+		visited[issue.IDReadable] = true
+		kids := children[issue.IDReadable]
+		row := treeRow{issue: issue, depth: depth, hasChildren: len(kids) > 0, collapsed: collapsed[issue.IDReadable]}
+		rows = append(rows, row)
+		if row.collapsed {
+			markVisited(kids, children, visited)
+			return
+		}
+		for _, kid := range kids {
+			walk(kid, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+
+	// Parent cycles leave issues unreachable from any root; show them flat.
+	for _, issue := range issues {
+		if !visited[issue.IDReadable] {
+			walk(issue, 0)
+		}
+	}
+
+	return rows
+}
+
+// markVisited marks a hidden subtree as placed so the cycle fallback in
+// buildIssueTree doesn't surface it.
+func markVisited(issues []model.Issue, children map[string][]model.Issue, visited map[string]bool) {
+	for _, issue := range issues {
+		if visited[issue.IDReadable] {
+			continue
+		}
+		visited[issue.IDReadable] = true
+		markVisited(children[issue.IDReadable], children, visited)
+	}
+}
+
+// treePrefix returns the indentation and fold marker for a tree row title.
+func treePrefix(depth int, hasChildren, collapsed bool) string {
+	marker := "  "
+	if hasChildren {
+		marker = "▾ "
+		if collapsed {
+			marker = "▸ "
+		}
+	}
+	return strings.Repeat("  ", depth) + marker
+}
+
+// listItems builds the issue list items for the current mode.
+func (a *App) listItems() []list.Item {
+	if !a.treeMode {
+		items := make([]list.Item, len(a.issues))
+		for i, issue := range a.issues {
+			items[i] = issueItem{issue: issue}
+		}
+		return items
+	}
+	rows := buildIssueTree(a.issues, a.collapsedIssues)
+	items := make([]list.Item, len(rows))
+	for i, row := range rows {
+		items[i] = issueItem{issue: row.issue, tree: true, depth: row.depth, hasChildren: row.hasChildren, collapsed: row.collapsed}
+	}
+	return items
+}
+
+// refreshListItems rebuilds the list items, keeping the cursor on the same
+// issue when it is still visible.
+func (a *App) refreshListItems() {
+	current := ""
+	if item, ok := a.list.SelectedItem().(issueItem); ok {
+		current = item.issue.IDReadable
+	}
+	a.list.SetItems(a.listItems())
+	a.selectListIssue(current)
+}
+
+// selectListIssue moves the list cursor to the issue with the given ID.
+// Returns false if it is not in the list.
+func (a *App) selectListIssue(issueID string) bool {
+	if issueID == "" {
+		return false
+	}
+	for i, it := range a.list.Items() {
+		if item, ok := it.(issueItem); ok && item.issue.IDReadable == issueID {
+			a.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// toggleFold collapses or expands the selected issue's subtasks. On a leaf,
+// it collapses the parent and moves the cursor there.
+func (a *App) toggleFold() {
+	item, ok := a.list.SelectedItem().(issueItem)
+	if !ok {
+		return
+	}
+	target := item.issue.IDReadable
+	if !item.hasChildren {
+		parent := item.issue.ParentID()
+		if item.depth == 0 || parent == "" {
+			return
+		}
+		target = parent
+	}
+	if a.collapsedIssues == nil {
+		a.collapsedIssues = map[string]bool{}
+	}
+	if a.collapsedIssues[target] {
+		delete(a.collapsedIssues, target)
+	} else {
+		a.collapsedIssues[target] = true
+	}
+	a.list.SetItems(a.listItems())
+	a.selectListIssue(target)
+}
+
+// subtaskLinkID returns the link ID that makes an issue a subtask of another
+// ("subtask of" direction of the Subtask link type), or "" if the server
+// defines no such type.
+func subtaskLinkID(types []model.IssueLinkType) string {
+	for _, t := range types {
+		if !t.Directed {
+			continue
+		}
+		if strings.EqualFold(t.TargetToSource, "subtask of") || t.Name == "Subtask" {
+			return t.ID + "t"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+func subtask(id, parent string) model.Issue {
+	issue := model.Issue{IDReadable: id, Summary: id}
+	if parent != "" {
+		issue.Parent = &model.IssueLink{Issues: []model.Issue{{IDReadable: parent}}}
+	}
+	return issue
+}
+
+func treeIDs(rows []treeRow) []string {
+	var ids []string
+	for _, r := range rows {
+		ids = append(ids, r.issue.IDReadable)
+	}
+	return ids
+}
+
+func TestBuildIssueTree(t *testing.T) {
+	issues := []model.Issue{
+		subtask("P-3", "P-1"),
+		subtask("P-1", ""),
+		subtask("P-4", "P-3"),
+		subtask("P-2", "P-1"),
+		subtask("P-5", "X-9"), // parent not loaded
+	}
+
+	rows := buildIssueTree(issues, nil)
+	want := []string{"P-1", "P-3", "P-4", "P-2", "P-5"}
+	got := treeIDs(rows)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if rows[0].depth != 0 || rows[1].depth != 1 || rows[2].depth != 2 || rows[4].depth != 0 {
+		t.Errorf("unexpected depths: %+v", rows)
+	}
+	if !rows[0].hasChildren || rows[3].hasChildren {
+		t.Error("unexpected hasChildren flags")
+	}
+}
+
+func TestBuildIssueTree_Collapsed(t *testing.T) {
+	issues := []model.Issue{subtask("P-1", ""), subtask("P-2", "P-1"), subtask("P-3", "P-2")}
+
+	rows := buildIssueTree(issues, map[string]bool{"P-1": true})
+	if len(rows) != 1 || !rows[0].collapsed {
+		t.Errorf("expected only collapsed P-1, got %v", treeIDs(rows))
+	}
+}
+
+func TestBuildIssueTree_Cycle(t *testing.T) {
+	issues := []model.Issue{subtask("P-1", "P-2"), subtask("P-2", "P-1")}
+
+	rows := buildIssueTree(issues, nil)
+	if len(rows) != 2 {
+		t.Errorf("expected both issues despite the cycle, got %v", treeIDs(rows))
+	}
+}
+
+func TestTreeMode_ToggleAndFold(t *testing.T) {
+	app := NewApp(&mockService{}, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+	app.resizePanels()
+	app.Update(issuesLoadedMsg{issues: []model.Issue{subtask("P-2", "P-1"), subtask("P-1", "")}})
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	if !app.treeMode {
+		t.Fatal("expected tree mode after space h")
+	}
+	first := app.list.Items()[0].(issueItem)
+	if first.issue.IDReadable != "P-1" || !first.hasChildren {
+		t.Errorf("expected parent P-1 first, got %+v", first)
+	}
+
+	// z on the subtask folds its parent and moves the cursor there
+	app.selectListIssue("P-2")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if len(app.list.Items()) != 1 {
+		t.Fatalf("expected subtask hidden, got %d items", len(app.list.Items()))
+	}
+	if item := app.list.SelectedItem().(issueItem); item.issue.IDReadable != "P-1" {
+		t.Errorf("expected cursor on P-1, got %s", item.issue.IDReadable)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if len(app.list.Items()) != 2 {
+		t.Errorf("expected subtask shown again, got %d items", len(app.list.Items()))
+	}
+}
+
+// subtaskService records the created issue and its subtask link.
+type subtaskService struct {
+	linkService
+	linkErr error
+}
+
+func (s *subtaskService) AddIssueLink(ctx context.Context, issueID, linkID, targetID string) error {
+	if s.linkErr != nil {
+		return s.linkErr
+	}
+	return s.linkService.AddIssueLink(ctx, issueID, linkID, targetID)
+}
+
+func (s *subtaskService) ListProjects(ctx context.Context) ([]model.Project, error) {
+	return []model.Project{{ID: "0-1", ShortName: "A"}, {ID: "0-2", ShortName: "P"}}, nil
+}
+
+func (s *subtaskService) ListLinkTypes(ctx context.Context) ([]model.IssueLinkType, error) {
+	return []model.IssueLinkType{{ID: "74-2", Name: "Subtask", SourceToTarget: "parent for", TargetToSource: "subtask of", Directed: true}}, nil
+}
+
+func (s *subtaskService) CreateIssue(ctx context.Context, projectID, summary, description string, customFields []map[string]any) (*model.Issue, error) {
+	return &model.Issue{IDReadable: "P-9"}, nil
+}
+
+func TestTreeMode_CreateLinksSubtask(t *testing.T) {
+	svc := &subtaskService{}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+	app.treeMode = true
+	app.selected = &model.Issue{IDReadable: "P-1", Project: &model.Project{ID: "0-2", ShortName: "P"}}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	app.Update(cmd())
+
+	if app.issueDialog.parentID != "P-1" {
+		t.Fatalf("got parentID %q, want P-1", app.issueDialog.parentID)
+	}
+	if app.issueDialog.projectIndex != 1 {
+		t.Errorf("expected parent's project preselected, got index %d", app.issueDialog.projectIndex)
+	}
+
+	app.issueDialog.summaryInput.SetValue("Child")
+	app.issueDialog.submitted = true
+	_, cmd = app.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected create cmd")
+	}
+	if msg := cmd(); msg != (issueCreatedMsg{}) {
+		t.Fatalf("got %#v, want issueCreatedMsg", msg)
+	}
+	if len(svc.added) != 1 || svc.added[0] != "P-9 74-2t P-1" {
+		t.Errorf("unexpected links: %v", svc.added)
+	}
+}
+
+func TestTreeMode_CreateReportsFailedSubtaskLink(t *testing.T) {
+	svc := &subtaskService{linkErr: errors.New("link refused")}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.linkTypes, _ = svc.ListLinkTypes(context.Background())
+	app.issueDialog.OpenCreate([]model.Project{{ID: "0-2", ShortName: "P"}}, &model.Issue{IDReadable: "P-1"})
+	app.issueDialog.summaryInput.SetValue("Child")
+	app.issueDialog.submitted = true
+
+	_, cmd := app.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	msg, ok := cmd().(issueCreatedMsg)
+	if !ok {
+		t.Fatalf("got %T, want issueCreatedMsg so the list refreshes", msg)
+	}
+	_, cmd = app.Update(msg)
+	if cmd == nil {
+		t.Error("expected list refresh")
+	}
+	if !strings.Contains(app.notice, "P-9") || !strings.Contains(app.notice, "P-1") {
+		t.Errorf("expected notice naming P-9 and P-1, got %q", app.notice)
+	}
+}
+
+func TestTreeMode_CreateWithoutSubtaskLinkType(t *testing.T) {
+	svc := &subtaskService{}
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	// linkService's types have no Subtask link
+	app.linkTypes, _ = svc.linkService.ListLinkTypes(context.Background())
+	app.issueDialog.OpenCreate([]model.Project{{ID: "0-2", ShortName: "P"}}, &model.Issue{IDReadable: "P-1"})
+	app.issueDialog.summaryInput.SetValue("Child")
+	app.issueDialog.submitted = true
+
+	_, cmd := app.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	app.Update(cmd())
+	if strings.Count(app.notice, "P-9") != 1 || strings.Count(app.notice, "P-1") != 1 {
+		t.Errorf("expected each ID named once, got %q", app.notice)
+	}
+	if !strings.Contains(app.notice, "no Subtask link type") {
+		t.Errorf("expected the reason in the notice, got %q", app.notice)
+	}
+}
+
+func TestSubtaskLinkID(t *testing.T) {
+	types := []model.IssueLinkType{
+		{ID: "74-0", Name: "Depend", SourceToTarget: "depends on", TargetToSource: "is required for", Directed: true},
+		{ID: "74-2", Name: "Subtask", SourceToTarget: "parent for", TargetToSource: "subtask of", Directed: true},
+	}
+	if got := subtaskLinkID(types); got != "74-2t" {
+		t.Errorf("subtaskLinkID() = %q, want %q", got, "74-2t")
+	}
+	if got := subtaskLinkID(types[:1]); got != "" {
+		t.Errorf("subtaskLinkID() = %q, want empty", got)
+	}
+}
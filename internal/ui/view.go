@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func (a *App) View() string {
@@ -22,19 +23,40 @@ func (a *App) View() string {
 	if a.projectPicker.active {
 		return a.projectPicker.View(a.width, a.height)
 	}
+	if a.savedSearches.active {
+		return a.savedSearches.View(a.width, a.height)
+	}
+	if a.sprintPicker.active {
+		return a.sprintPicker.View(a.width, a.height)
+	}
+	if a.tagDialog.active {
+		return a.tagDialog.View(a.width, a.height)
+	}
+	if a.workLogDialog.active {
+		return a.workLogDialog.View(a.width, a.height)
+	}
+	if a.linksDialog.active {
+		return a.linksDialog.View(a.width, a.height)
+	}
+	if a.attachmentDialog.active {
+		return a.attachmentDialog.View(a.width, a.height)
+	}
 	if a.showHelp {
 		return renderHelp(a.width, a.height)
 	}
 	if a.issueDialog.active {
 		return a.issueDialog.View(a.width, a.height)
 	}
+	if a.board.active {
+		return lipgloss.JoinVertical(lipgloss.Left, a.board.View(a.width, a.height-1), a.renderStatusBar())
+	}
 
 	var panels string
 	panelHeight := a.height - 3
 	if !a.listCollapsed {
 		panelHeight-- // filter bar takes 1 line inside the list panel
 	}
-	hasComments := a.hasComments()
+	hasSide := a.hasSidePanel()
 
 	// Determine detail panel title
 	detailTitle := iconFile + " Detail"
@@ -42,28 +64,26 @@ func (a *App) View() string {
 		detailTitle = iconFile + " " + a.selected.IDReadable
 	}
 
-	// Comments panel title with count
-	commentsTitle := ""
-	if hasComments {
-		commentsTitle = fmt.Sprintf("%s Comments (%d)", iconComment, len(a.selected.Comments))
+	// Comments or history panel title with count
+	var sideTitle, sideContent string
+	var sideFocused bool
+	if hasSide {
+		sideTitle, sideContent, sideFocused = a.sidePanel()
 	}
 
 	if a.listCollapsed {
 		if a.commenting {
 			innerWidth := a.width - 2
-			commentContent := lipgloss.NewStyle().Padding(1, 2).Render(
-				a.commentInput.View() + "\n\n" +
-					hintDescStyle.Render("ctrl+s: submit  esc: cancel"),
-			)
-			panels = renderTitledPanel(iconFile+" Add Comment", commentContent, innerWidth, panelHeight, true, lipgloss.Color("99"))
-		} else if hasComments {
+			commentContent := lipgloss.NewStyle().Padding(1, 2).Render(a.commentPanelContent(innerWidth - 4))
+			panels = renderTitledPanel(a.commentPanelTitle(), commentContent, innerWidth, panelHeight, true, lipgloss.Color("99"))
+		} else if hasSide {
 			detailOuter := a.width / 2
 			commentsOuter := a.width - detailOuter
 			innerDetailWidth := detailOuter - 2
 			innerCommentsWidth := commentsOuter - 2
 
 			detailPanel := renderTitledPanel(detailTitle, a.detail.View(), innerDetailWidth, panelHeight, a.focus == detailPane, lipgloss.Color("69"))
-			commentsPanel := renderTitledPanel(commentsTitle, a.comments.View(), innerCommentsWidth, panelHeight, a.focus == commentsPane, lipgloss.Color("99"))
+			commentsPanel := renderTitledPanel(sideTitle, sideContent, innerCommentsWidth, panelHeight, sideFocused, lipgloss.Color("99"))
 			panels = lipgloss.JoinHorizontal(lipgloss.Top, detailPanel, commentsPanel)
 		} else {
 			innerWidth := a.width - 2
@@ -75,18 +95,19 @@ func (a *App) View() string {
 
 		filterBar := a.renderFilterBar(innerListWidth)
 		listContent := filterBar + "\n" + a.list.View()
-		leftPanel := renderTitledPanel(iconList+" Issues", listContent, innerListWidth, panelHeight, a.focus == listPane, lipgloss.Color("78"))
+		listTitle := iconList + " Issues"
+		if a.treeMode {
+			listTitle += " (tree)"
+		}
+		leftPanel := renderTitledPanel(listTitle, listContent, innerListWidth, panelHeight, a.focus == listPane, lipgloss.Color("78"))
 
 		if a.commenting {
 			detailWidth := a.width - listWidth
 			innerDetailWidth := detailWidth - 2
-			commentContent := lipgloss.NewStyle().Padding(1, 2).Render(
-				a.commentInput.View() + "\n\n" +
-					hintDescStyle.Render("ctrl+s: submit  esc: cancel"),
-			)
-			rightPanel := renderTitledPanel(iconFile+" Add Comment", commentContent, innerDetailWidth, panelHeight, true, lipgloss.Color("99"))
+			commentContent := lipgloss.NewStyle().Padding(1, 2).Render(a.commentPanelContent(innerDetailWidth - 4))
+			rightPanel := renderTitledPanel(a.commentPanelTitle(), commentContent, innerDetailWidth, panelHeight, true, lipgloss.Color("99"))
 			panels = lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
-		} else if hasComments {
+		} else if hasSide {
 			remaining := a.width - listWidth
 			detailOuter := remaining / 2
 			commentsOuter := remaining - detailOuter
@@ -94,7 +115,7 @@ func (a *App) View() string {
 			innerCommentsWidth := commentsOuter - 2
 
 			detailPanel := renderTitledPanel(detailTitle, a.detail.View(), innerDetailWidth, panelHeight, a.focus == detailPane, lipgloss.Color("69"))
-			commentsPanel := renderTitledPanel(commentsTitle, a.comments.View(), innerCommentsWidth, panelHeight, a.focus == commentsPane, lipgloss.Color("99"))
+			commentsPanel := renderTitledPanel(sideTitle, sideContent, innerCommentsWidth, panelHeight, sideFocused, lipgloss.Color("99"))
 			panels = lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, detailPanel, commentsPanel)
 		} else {
 			detailWidth := a.width - listWidth
@@ -105,8 +126,10 @@ func (a *App) View() string {
 	}
 
 	var bottom string
-	if a.searching {
-		bottom = a.searchInput.View()
+	if a.searching && a.queryHistory.recall {
+		bottom = a.queryHistory.View()
+	} else if a.searching {
+		bottom = a.searchPromptView()
 	} else if a.settingState {
 		bottom = a.stateInput.View()
 	} else if a.assigning {
@@ -115,6 +138,8 @@ func (a *App) View() string {
 		bottom = a.gotoInput.View()
 	} else if a.confirmDelete && a.selected != nil {
 		bottom = errorStyle.Render(fmt.Sprintf("Delete %s? (y/n)", a.selected.IDReadable))
+	} else if c := a.comments.Selected(); a.confirmCommentDelete && c != nil {
+		bottom = errorStyle.Render(fmt.Sprintf("Delete comment by %s? (y/n)", commentAuthor(c)))
 	} else {
 		bottom = a.renderStatusBar()
 	}
@@ -125,12 +150,32 @@ func (a *App) View() string {
 		popup := a.renderLeaderPopup()
 		layout = overlayBottomRight(layout, popup, a.width, a.height)
 	}
+	if a.searching && !a.queryHistory.recall {
+		if popup := a.queryAssist.View(a.width); popup != "" {
+			layout = overlayAbove(layout, popup, 0)
+		}
+	}
 
 	return layout
 }
 
-func (a *App) hasComments() bool {
-	return a.selected != nil && len(a.selected.Comments) > 0
+// hasSidePanel reports whether the right column is shown: history when
+// toggled on, otherwise comments when the issue has any.
+func (a *App) hasSidePanel() bool {
+	return a.selected != nil && (a.showHistory || len(a.selected.Comments) > 0)
+}
+
+// sidePanel returns the right column's title, content and focus state.
+func (a *App) sidePanel() (title, content string, focused bool) {
+	if a.showHistory {
+		title = iconHistory + " History"
+		if a.activities != nil {
+			title = fmt.Sprintf("%s History (%d)", iconHistory, len(a.activities))
+		}
+		return title, a.history.View(), a.focus == historyPane
+	}
+	title = fmt.Sprintf("%s Comments (%d)", iconComment, len(a.selected.Comments))
+	return title, a.comments.View(), a.focus == commentsPane
 }
 
 func (a *App) renderFilterBar(width int) string {
@@ -189,12 +234,17 @@ func (a *App) renderLeaderPopup() string {
 }
 
 func overlayBottomRight(bg, fg string, width, height int) string {
+	return overlayAbove(bg, fg, width-lipgloss.Width(fg))
+}
+
+// overlayAbove places fg over bg from column startCol, with its last line
+// just above bg's last line. The rest of each covered line is kept.
+func overlayAbove(bg, fg string, startCol int) string {
 	bgLines := strings.Split(bg, "\n")
 	fgLines := strings.Split(fg, "\n")
 
 	fgWidth := lipgloss.Width(fg)
 	startRow := len(bgLines) - len(fgLines) - 1 // -1 to stay above status bar
-	startCol := width - fgWidth
 
 	if startRow < 0 {
 		startRow = 0
@@ -214,56 +264,48 @@ func overlayBottomRight(bg, fg string, width, height int) string {
 		if bgLineWidth < startCol {
 			bgLine += strings.Repeat(" ", startCol-bgLineWidth)
 		}
-		// Replace segment: keep left part, overlay fg, keep right part
+		// Replace segment: keep left part, overlay fg, keep right part.
+		// Wide characters cut in half by the overlay become spaces so the
+		// rest of the line stays in its columns.
 		leftPart := ansiTruncate(bgLine, startCol)
-		bgLines[row] = leftPart + fgLine
+		leftPart += strings.Repeat(" ", startCol-ansi.StringWidth(leftPart))
+		bgLines[row] = leftPart + fgLine + ansiSkip(bgLine, startCol+fgWidth)
 	}
 
 	return strings.Join(bgLines, "\n")
 }
 
-// ansiTruncate truncates a string to maxWidth visible characters,
-// preserving ANSI escape sequences.
+// ansiTruncate truncates a string to maxWidth terminal cells, preserving
+// ANSI escape sequences. A wide character that would straddle the edge is
+// dropped, so the result may be one cell short.
 func ansiTruncate(s string, maxWidth int) string {
-	var result strings.Builder
-	visible := 0
-	inEscape := false
-
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			result.WriteRune(r)
-			continue
-		}
-		if inEscape {
-			result.WriteRune(r)
-			if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
-				inEscape = false
-			}
-			continue
-		}
-		if visible >= maxWidth {
-			break
-		}
-		result.WriteRune(r)
-		visible++
-	}
+	return ansi.Truncate(s, maxWidth, "")
+}
 
-	return result.String()
+// ansiSkip drops the first n terminal cells of a string, keeping its ANSI
+// escape sequences so the rest is styled as before. A wide character that
+// straddles the cut is replaced by a space, so the rest keeps its columns.
+func ansiSkip(s string, n int) string {
+	rest := ansi.TruncateLeft(s, n, "")
+	if ansi.StringWidth(rest) > ansi.StringWidth(s)-n {
+		rest = " " + ansi.TruncateLeft(s, n+1, "")
+	}
+	return rest
 }
 
 func (a *App) resizePanels() {
 	panelHeight := a.height - 5
-	hasComments := a.hasComments()
+	hasSide := a.hasSidePanel()
 
 	if a.listCollapsed {
-		if hasComments {
+		if hasSide {
 			detailOuter := a.width / 2
 			commentsOuter := a.width - detailOuter
 			a.detail.Width = detailOuter - 4
 			a.detail.Height = panelHeight
-			a.comments.Width = commentsOuter - 4
-			a.comments.Height = panelHeight
+			a.comments.SetSize(commentsOuter-4, panelHeight)
+			a.history.Width = commentsOuter - 4
+			a.history.Height = panelHeight
 		} else {
 			a.detail.Width = a.width - 4
 			a.detail.Height = panelHeight
@@ -273,14 +315,15 @@ func (a *App) resizePanels() {
 		listWidth := listOuter - 4
 		a.list.SetSize(listWidth, panelHeight-1) // -1 for filter bar line
 
-		if hasComments {
+		if hasSide {
 			remaining := a.width - listOuter
 			detailOuter := remaining / 2
 			commentsOuter := remaining - detailOuter
 			a.detail.Width = detailOuter - 4
 			a.detail.Height = panelHeight
-			a.comments.Width = commentsOuter - 4
-			a.comments.Height = panelHeight
+			a.comments.SetSize(commentsOuter-4, panelHeight)
+			a.history.Width = commentsOuter - 4
+			a.history.Height = panelHeight
 		} else {
 			detailWidth := a.width - listOuter - 4
 			a.detail.Width = detailWidth
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cf/lazytrack/internal/model"
+)
+
+type workLogField int
+
+const (
+	workLogDuration workLogField = iota
+	workLogType
+	workLogText
+	workLogDate
+	workLogFieldCount
+)
+
+const workLogDateLayout = "2006-01-02"
+
+// WorkLogDialog is a centered popup for logging time on an issue.
+type WorkLogDialog struct {
+	issueID        string
+	projectID      string
+	durationInput  textinput.Model
+	textInput      textinput.Model
+	dateInput      textinput.Model
+	types          []model.WorkItemType
+	typeIndex      int  // 0 = no type; types start at 1
+	typesRequested bool // app should load work types for projectID and call SetTypes
+	loadingTypes   bool
+	focus          workLogField
+	active         bool
+	submitted      bool
+	item           model.WorkItem // set on submit
+	err            string
+}
+
+func NewWorkLogDialog() WorkLogDialog {
+	di := textinput.New()
+	di.Placeholder = "1h30m"
+	di.Prompt = ""
+	di.CharLimit = 32
+
+	ti := textinput.New()
+	ti.Placeholder = "What did you work on?"
+	ti.Prompt = ""
+	ti.CharLimit = 1000
+
+	dti := textinput.New()
+	dti.Placeholder = workLogDateLayout
+	dti.Prompt = ""
+	dti.CharLimit = 10
+
+	return WorkLogDialog{durationInput: di, textInput: ti, dateInput: dti}
+}
+
+// Open activates the dialog for an issue. Work types are requested when
+// none are known for the issue's project yet.
+func (d *WorkLogDialog) Open(issue *model.Issue, types []model.WorkItemType) tea.Cmd {
+	d.issueID = issue.IDReadable
+	d.projectID = ""
+	if issue.Project != nil {
+		d.projectID = issue.Project.ID
+	}
+	d.durationInput.SetValue("")
+	d.textInput.SetValue("")
+	d.dateInput.SetValue(time.Now().Format(workLogDateLayout))
+	d.types = types
+	d.typeIndex = 0
+	d.typesRequested = types == nil && d.projectID != ""
+	d.loadingTypes = d.typesRequested
+	d.active = true
+	d.submitted = false
+	d.item = model.WorkItem{}
+	d.err = ""
+	d.focus = workLogDuration
+	return d.updateFocus()
+}
+
+func (d *WorkLogDialog) Close() {
+	d.active = false
+	d.durationInput.Blur()
+	d.textInput.Blur()
+	d.dateInput.Blur()
+}
+
+// SetTypes provides the project's work types.
+func (d *WorkLogDialog) SetTypes(types []model.WorkItemType) {
+	d.loadingTypes = false
+	d.types = types
+	d.typeIndex = 0
+}
+
+// SetError shows err inside the dialog.
+func (d *WorkLogDialog) SetError(err string) {
+	d.loadingTypes = false
+	d.err = err
+}
+
+func (d *WorkLogDialog) updateFocus() tea.Cmd {
+	d.durationInput.Blur()
+	d.textInput.Blur()
+	d.dateInput.Blur()
+	switch d.focus {
+	case workLogDuration:
+		return d.durationInput.Focus()
+	case workLogText:
+		return d.textInput.Focus()
+	case workLogDate:
+		return d.dateInput.Focus()
+	}
+	return nil
+}
+
+// buildItem validates the form and returns the work item to create.
+func (d *WorkLogDialog) buildItem() (model.WorkItem, error) {
+	minutes, err := model.ParseDuration(d.durationInput.Value())
+	if err != nil {
+		return model.WorkItem{}, fmt.Errorf("duration: %w", err)
+	}
+	date, err := time.ParseInLocation(workLogDateLayout, strings.TrimSpace(d.dateInput.Value()), time.Local)
+	if err != nil {
+		return model.WorkItem{}, fmt.Errorf("date must be %s", workLogDateLayout)
+	}
+	item := model.WorkItem{
+		Date:     date.UnixMilli(),
+		Duration: model.Period{Minutes: minutes},
+		Text:     strings.TrimSpace(d.textInput.Value()),
+	}
+	if d.typeIndex > 0 {
+		t := d.types[d.typeIndex-1]
+		item.Type = &t
+	}
+	return item, nil
+}
+
+func (d *WorkLogDialog) Update(msg tea.Msg) (WorkLogDialog, tea.Cmd) {
+	if !d.active {
+		return *d, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return *d, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		d.Close()
+		return *d, nil
+	case "enter", "ctrl+s":
+		item, err := d.buildItem()
+		if err != nil {
+			d.err = err.Error()
+			return *d, nil
+		}
+		d.item = item
+		d.submitted = true
+		d.Close()
+		return *d, nil
+	case "tab", "down":
+		d.focus = (d.focus + 1) % workLogFieldCount
+		return *d, d.updateFocus()
+	case "shift+tab", "up":
+		d.focus = (d.focus + workLogFieldCount - 1) % workLogFieldCount
+		return *d, d.updateFocus()
+	}
+
+	var cmd tea.Cmd
+	switch d.focus {
+	case workLogDuration:
+		d.durationInput, cmd = d.durationInput.Update(msg)
+	case workLogType:
+		switch keyMsg.String() {
+		case "left", "h":
+			if d.typeIndex > 0 {
+				d.typeIndex--
+			}
+		case "right", "l":
+			if d.typeIndex < len(d.types) {
+				d.typeIndex++
+			}
+		}
+	case workLogText:
+		d.textInput, cmd = d.textInput.Update(msg)
+	case workLogDate:
+		d.dateInput, cmd = d.dateInput.Update(msg)
+	}
+	return *d, cmd
+}
+
+func (d *WorkLogDialog) View(width, height int) string {
+	if !d.active {
+		return ""
+	}
+
+	dialogWidth := width * 2 / 5
+	if dialogWidth < 50 {
+		dialogWidth = 50
+	}
+
+	labelFocused := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69"))
+	labelNormal := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	label := func(name string, field workLogField) string {
+		style := labelNormal
+		if d.focus == field {
+			style = labelFocused
+		}
+		return style.Width(10).Render(name + ":")
+	}
+
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Log work — "+d.issueID) + "\n\n")
+	b.WriteString(label("Duration", workLogDuration) + " " + d.durationInput.View() + "\n")
+
+	typeName := "(none)"
+	if d.typeIndex > 0 {
+		typeName = d.types[d.typeIndex-1].Name
+	}
+	switch {
+	case d.loadingTypes:
+		typeName = "loading..."
+	case d.focus == workLogType:
+		typeName = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69")).Render("< " + typeName + " >")
+	}
+	b.WriteString(label("Type", workLogType) + " " + typeName + "\n")
+	b.WriteString(label("Note", workLogText) + " " + d.textInput.View() + "\n")
+	b.WriteString(label("Date", workLogDate) + " " + d.dateInput.View() + "\n")
+
+	if d.err != "" {
+		b.WriteString("\n" + errorStyle.Render("Error: "+d.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	b.WriteString(hint.Render("tab: next field  h/l: work type  enter: log  esc: cancel"))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("99")).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	dialog := dialogStyle.Render(b.String())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog)
+}
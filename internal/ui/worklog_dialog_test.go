@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cf/lazytrack/internal/config"
+	"github.com/cf/lazytrack/internal/model"
+)
+
+// workService records logged work and serves fixed work types.
+type workService struct {
+	mockService
+	typeCalls int
+	logged    []model.WorkItem
+}
+
+func (s *workService) ListWorkItemTypes(ctx context.Context, projectID string) ([]model.WorkItemType, error) {
+	s.typeCalls++
+	return []model.WorkItemType{{ID: "wt-1", Name: "Development"}, {ID: "wt-2", Name: "Testing"}}, nil
+}
+
+func (s *workService) CreateWorkItem(ctx context.Context, issueID string, item model.WorkItem) (*model.WorkItem, error) {
+	s.logged = append(s.logged, item)
+	return &item, nil
+}
+
+func typeRunes(app *App, s string) {
+	for _, r := range s {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func newWorkLogTestApp(svc IssueService) (*App, tea.Cmd) {
+	app := NewApp(svc, config.DefaultState())
+	app.ready = true
+	app.width = 120
+	app.height = 40
+	app.selected = &model.Issue{IDReadable: "PROJ-1", Project: &model.Project{ID: "0-1", ShortName: "PROJ"}}
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	return app, cmd
+}
+
+func TestWorkLogDialog_RejectsBadDuration(t *testing.T) {
+	d := NewWorkLogDialog()
+	d.Open(&model.Issue{IDReadable: "PROJ-1"}, nil)
+	d.durationInput.SetValue("soon")
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if d.submitted || !d.active {
+		t.Fatal("expected invalid duration to keep the dialog open")
+	}
+	if !strings.Contains(d.err, "duration") {
+		t.Errorf("got error %q, want a duration error", d.err)
+	}
+}
+
+func TestWorkLog_SubmitWithType(t *testing.T) {
+	svc := &workService{}
+	app, cmd := newWorkLogTestApp(svc)
+	if !app.workLogDialog.active {
+		t.Fatal("expected work log dialog after space w")
+	}
+	if cmd == nil {
+		t.Fatal("expected work type fetch")
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, fn := range batch {
+			if fn == nil {
+				continue
+			}
+			if msg, ok := fn().(workItemTypesLoadedMsg); ok {
+				app.Update(msg)
+			}
+		}
+	}
+	if len(app.workLogDialog.types) != 2 {
+		t.Fatalf("got %d work types, want 2", len(app.workLogDialog.types))
+	}
+
+	typeRunes(app, "1h30m")
+	app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeRunes(app, "ll")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected log work cmd")
+	}
+	msg, ok := cmd().(workLoggedMsg)
+	if !ok {
+		t.Fatal("expected workLoggedMsg")
+	}
+	if len(svc.logged) != 1 {
+		t.Fatalf("got %d logged items, want 1", len(svc.logged))
+	}
+	item := svc.logged[0]
+	if item.Duration.Minutes != 90 || item.Type == nil || item.Type.Name != "Testing" {
+		t.Errorf("unexpected work item: %+v", item)
+	}
+
+	app.Update(msg)
+	if app.notice != "Logged 1h 30m on PROJ-1" {
+		t.Errorf("got notice %q", app.notice)
+	}
+
+	// Reopening uses the cached types
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if svc.typeCalls != 1 || len(app.workLogDialog.types) != 2 {
+		t.Errorf("expected cached work types, got %d fetches", svc.typeCalls)
+	}
+}
+
+func TestRenderTimeSummary(t *testing.T) {
+	issue := &model.Issue{
+		CustomFields: []model.CustomField{{Name: "Estimation", Value: json.RawMessage(`{"minutes":300}`)}},
+	}
+	items := []model.WorkItem{
+		{Duration: model.Period{Minutes: 120}},
+		{Duration: model.Period{Minutes: 30}},
+	}
+
+	if got, want := renderTimeSummary(issue, items), "2h 30m logged / 5h estimated (50%)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := renderTimeSummary(&model.Issue{}, items), "2h 30m logged"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := renderTimeSummary(&model.Issue{}, nil); got != "" {
+		t.Errorf("got %q, want empty", got)
+	}
+}